@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"maps"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 
 	"github.com/adrock-miles/go-laserbeak/internal/application"
@@ -79,7 +82,67 @@ func runServe(cmd *cobra.Command, args []string) error {
 		}
 
 		playOpts := playoptions.NewComposite(playOptsSources...)
-		voiceService := application.NewVoiceService(sttClient, cfg.Bot.WakePhrase, llmClient, playOpts)
+		voiceService, err := application.NewVoiceServiceChecked(sttClient, cfg.Bot.WakePhrase, llmClient, playOpts)
+		if err != nil {
+			return fmt.Errorf("create voice service: %w", err)
+		}
+		llmPolicy := application.LLMLimitWait
+		if cfg.LLM.BusyPassthrough {
+			llmPolicy = application.LLMLimitPassthrough
+		}
+		voiceService.SetLLMConcurrency(cfg.LLM.MaxConcurrent, llmPolicy)
+		voiceService.SetLocalMatching(cfg.PlayOptions.LocalMatching)
+		voiceService.SetPhoneticMatching(cfg.PlayOptions.Phonetic)
+		voiceService.SetFuzzyKeywords(cfg.Bot.FuzzyKeywords)
+		voiceService.SetWakeSensitivity(cfg.Bot.WakeSensitivity)
+		voiceService.SetAllowTrailingWake(cfg.Bot.TrailingWake)
+		voiceService.SetFirstWordWake(cfg.Bot.FirstWordWake)
+		voiceService.SetNoiseWords(cfg.Bot.NoiseWords)
+		voiceService.AddPlaceholderTokens(cfg.Bot.Placeholders...)
+		voiceService.SetMinCommandWords(cfg.Bot.MinCommandWords)
+		voiceService.SetMaxInterveningWords(cfg.Bot.MaxIntervening)
+		voiceService.SetBareWakeCommand(cfg.Bot.BareWakeCommand)
+		voiceService.AddHomophones(cfg.Bot.Homophones)
+		if err := voiceService.SetTurnOffCommand(cfg.Bot.TurnOffCommand); err != nil {
+			return fmt.Errorf("set voice turn off command: %w", err)
+		}
+		if fb := cfg.STT.Fallback; fb.APIKey != "" {
+			voiceService.SetSTTFallbacks(llm.NewSTTClient(fb.APIKey, fb.BaseURL, fb.Model))
+			log.Printf("Fallback STT enabled (%s, model %s)", fb.BaseURL, fb.Model)
+		}
+		voiceService.SetTranscriptionCache(cfg.STT.CacheTTL)
+		voiceService.SetOptionCacheTTL(cfg.PlayOptions.CacheTTL)
+		voiceService.SetMaxTranscribeChunkBytes(cfg.STT.MaxChunkBytes)
+		if err := voiceService.SetSpeakerLabels(cfg.STT.SpeakerLabels); err != nil {
+			return fmt.Errorf("set speaker labels: %w", err)
+		}
+		voiceService.SetUserDenylist(cfg.Bot.DeniedUsers)
+		voiceService.SetUserAllowlist(cfg.Bot.AllowedUsers)
+		voiceService.SetAdminUsers(cfg.Bot.AdminUsers)
+		if cfg.Bot.HistoryFile != "" {
+			store := persistence.NewFileHistoryStore(cfg.Bot.HistoryFile)
+			if err := voiceService.SetHistoryStore(context.Background(), store); err != nil {
+				return fmt.Errorf("set voice history store: %w", err)
+			}
+		}
+		if len(cfg.Bot.RandomSynonyms) > 0 {
+			voiceService.SetRandomSynonyms(cfg.Bot.RandomSynonyms)
+		}
+		voiceService.SetRandomCommandOutput(cfg.Bot.RandomOutput)
+		for _, name := range cfg.Bot.DisabledCommands {
+			voiceService.SetCommandEnabled(name, false)
+		}
+		for _, phrase := range slices.Sorted(maps.Keys(cfg.Bot.Aliases)) {
+			if err := voiceService.AddAlias(cfg.Bot.Aliases[phrase], phrase); err != nil {
+				return fmt.Errorf("add voice alias: %w", err)
+			}
+		}
+		for name, prefix := range cfg.Bot.CommandPrefixes {
+			voiceService.SetCommandPrefixFor(name, prefix)
+		}
+		if err := voiceService.Warmup(context.Background()); err != nil {
+			log.Printf("Voice warmup failed, continuing: %v", err)
+		}
 		discordBot.SetVoiceHandler(voiceService.HandleVoice)
 		log.Printf("Voice commands enabled (wake phrase: %q)", cfg.Bot.WakePhrase)
 
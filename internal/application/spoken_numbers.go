@@ -0,0 +1,79 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+)
+
+// numberUnits maps number words below twenty to their values.
+var numberUnits = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16,
+	"seventeen": 17, "eighteen": 18, "nineteen": 19,
+}
+
+// numberTens maps multiples of ten from twenty to ninety to their values.
+var numberTens = map[string]int{
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+// normalizeSpokenNumbers replaces spelled-out numbers from zero to one hundred
+// in lowercase text with digits, e.g. "volume twenty five" → "volume 25".
+// Compounds may be separate words, hyphenated ("twenty-five") or run together
+// ("twentyfive", as left by punctuation stripping). "hundred", "a hundred" and
+// "one hundred" become 100. Other words are kept as they are.
+func normalizeSpokenNumbers(text string) string {
+	words := strings.Fields(text)
+	out := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		next := ""
+		if i+1 < len(words) {
+			next = words[i+1]
+		}
+
+		switch {
+		case word == "hundred":
+			out = append(out, "100")
+		case (word == "a" || word == "one") && next == "hundred":
+			out = append(out, "100")
+			i++
+		default:
+			n, ok := spokenNumber(word)
+			if !ok {
+				out = append(out, word)
+				continue
+			}
+			if unit, isUnit := numberUnits[next]; isUnit && n >= 20 && n%10 == 0 && unit > 0 && unit < 10 {
+				n += unit
+				i++
+			}
+			out = append(out, strconv.Itoa(n))
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// spokenNumber parses a single number word, including hyphenated or run-together
+// compounds such as "twenty-five" and "twentyfive".
+func spokenNumber(word string) (int, bool) {
+	if n, ok := numberUnits[word]; ok {
+		return n, true
+	}
+	for tensWord, tens := range numberTens {
+		rest, found := strings.CutPrefix(word, tensWord)
+		if !found {
+			continue
+		}
+		rest = strings.TrimPrefix(rest, "-")
+		if rest == "" {
+			return tens, true
+		}
+		if unit, ok := numberUnits[rest]; ok && unit > 0 && unit < 10 {
+			return tens + unit, true
+		}
+	}
+	return 0, false
+}
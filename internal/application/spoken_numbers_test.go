@@ -0,0 +1,66 @@
+package application
+
+import (
+	"strconv"
+	"testing"
+)
+
+// spell writes n (0–100) out in words the way STT tends to transcribe it.
+func spell(n int) string {
+	units := []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	tens := []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	switch {
+	case n == 100:
+		return "one hundred"
+	case n < 20:
+		return units[n]
+	case n%10 == 0:
+		return tens[n/10]
+	default:
+		return tens[n/10] + " " + units[n%10]
+	}
+}
+
+func TestNormalizeSpokenNumbers_FullRange(t *testing.T) {
+	for n := 0; n <= 100; n++ {
+		input := spell(n)
+		if got := normalizeSpokenNumbers(input); got != strconv.Itoa(n) {
+			t.Errorf("normalizeSpokenNumbers(%q) = %q, want %q", input, got, strconv.Itoa(n))
+		}
+	}
+}
+
+func TestNormalizeSpokenNumbers(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"in a sentence", "volume twenty five please", "volume 25 please"},
+		{"hyphenated", "twenty-five", "25"},
+		{"run together", "twentyfive", "25"},
+		{"a hundred", "a hundred", "100"},
+		{"bare hundred", "hundred", "100"},
+		{"one hundred", "volume one hundred", "volume 100"},
+		{"tens alone", "fifty", "50"},
+		{"teen", "volume fifteen", "volume 15"},
+		{"zero", "zero", "0"},
+		{"multiple numbers", "seek one then forty two", "seek 1 then 42"},
+		{"digits unchanged", "volume 50", "volume 50"},
+		{"non-number words unchanged", "play something else", "play something else"},
+		{"a without hundred", "play a song", "play a song"},
+		{"teen does not combine", "fifteen five", "15 5"},
+		{"unit does not combine with zero", "twenty zero", "20 0"},
+		{"partial word unchanged", "tenth", "tenth"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeSpokenNumbers(tt.input); got != tt.want {
+				t.Errorf("normalizeSpokenNumbers(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownCommand is returned when an alias names a command that doesn't exist.
+	ErrUnknownCommand = errors.New("unknown voice command")
+	// ErrAliasShadowsBuiltin is returned by AddAlias when a built-in command
+	// already claims the phrase.
+	ErrAliasShadowsBuiltin = errors.New("alias phrase is already a built-in command")
+)
+
+// commandAlias maps an extra phrase to a keyword command.
+type commandAlias struct {
+	phrase   string // normalized like command words
+	command  keywordCommand
+	override bool // checked before the built-in commands
+}
+
+// AddAlias maps an extra phrase to an existing keyword command, e.g. "halt" to
+// "stop" or "bop" to "pr" (play random). The phrase is matched like built-in
+// phrases, after the wake phrase and fillers, and may start a compound command.
+// Returns ErrAliasShadowsBuiltin if a built-in command already claims the
+// phrase; use AddAliasOverride to take it over deliberately.
+func (s *VoiceService) AddAlias(command, phrase string) error {
+	return s.addAlias(command, phrase, false)
+}
+
+// AddAliasOverride is like AddAlias but the alias takes precedence over the
+// built-in commands, e.g. to make "stop" mean "pause".
+func (s *VoiceService) AddAliasOverride(command, phrase string) error {
+	return s.addAlias(command, phrase, true)
+}
+
+func (s *VoiceService) addAlias(command, phrase string, override bool) error {
+	kc, ok := aliasTarget(strings.ToLower(strings.TrimSpace(command)))
+	if !ok {
+		return fmt.Errorf("alias %q: %w: %q", phrase, ErrUnknownCommand, command)
+	}
+	normalized := newCommandWords(strings.Fields(phrase)).text()
+	if normalized == "" {
+		return fmt.Errorf("alias for %q: phrase must not be empty", command)
+	}
+	if !override && (s.startsBuiltinCommand(normalized) || parsesAsVolume(normalized)) {
+		return fmt.Errorf("alias %q: %w", phrase, ErrAliasShadowsBuiltin)
+	}
+	s.aliases = append(s.aliases, commandAlias{phrase: normalized, command: kc, override: override})
+	return nil
+}
+
+// aliasTarget returns the keyword command an alias may map to. Besides the
+// keyword table, "pr" (play random) is accepted.
+func aliasTarget(name string) (keywordCommand, bool) {
+	if name == "pr" {
+		return keywordCommand{name: "pr"}, true
+	}
+	for _, kc := range keywordCommands {
+		if kc.name == name {
+			return kc, true
+		}
+	}
+	return keywordCommand{}, false
+}
+
+// parsesAsVolume reports whether text is already a volume command.
+func parsesAsVolume(text string) bool {
+	_, ok := parseVolume(text)
+	return ok
+}
+
+// matchAlias returns the first alias, overriding or not, whose phrase starts text.
+func (s *VoiceService) matchAlias(text string, override bool) *commandAlias {
+	for i := range s.aliases {
+		a := &s.aliases[i]
+		if a.override == override && hasPhrasePrefix(text, a.phrase) {
+			return a
+		}
+	}
+	return nil
+}
+
+// startsAlias reports whether text starts with any alias phrase.
+func (s *VoiceService) startsAlias(text string) bool {
+	return s.matchAlias(text, true) != nil || s.matchAlias(text, false) != nil
+}
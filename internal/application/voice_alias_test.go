@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAddAlias(t *testing.T) {
+	svc := newTestService()
+	if err := svc.AddAlias("stop", "halt"); err != nil {
+		t.Fatalf("AddAlias(stop) error: %v", err)
+	}
+	if err := svc.AddAlias("pr", "Bop!"); err != nil {
+		t.Fatalf("AddAlias(pr) error: %v", err)
+	}
+	if err := svc.AddAlias("skip", "move along"); err != nil {
+		t.Fatalf("AddAlias(skip) error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"stop alias", "laser halt", "!stop"},
+		{"play random alias", "laser bop", "!pr"},
+		{"multi-word alias", "laser move along", "!skip"},
+		{"filler before wake", "hey laser halt", "!stop"},
+		{"filler after wake", "laser um halt", "!stop"},
+		{"casing and punctuation", "LASER Halt!", "!stop"},
+		{"whole words only", "laser halting", ""},
+		{"no wake phrase", "halt", ""},
+		{"built-ins still work", "laser stop", "!stop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddAlias_Compound(t *testing.T) {
+	svc := newTestService()
+	if err := svc.AddAlias("pr", "bop"); err != nil {
+		t.Fatalf("AddAlias error: %v", err)
+	}
+
+	cmds := svc.ParseCommands(context.Background(), "laser stop and then bop")
+	if len(cmds) != 2 || cmds[0].Text != "!stop" || cmds[1].Text != "!pr" {
+		t.Errorf("ParseCommands = %+v, want !stop then !pr", cmds)
+	}
+}
+
+func TestAddAlias_Errors(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name    string
+		command string
+		phrase  string
+		want    error
+	}{
+		{"unknown command", "explode", "boom", ErrUnknownCommand},
+		{"shadows keyword", "pause", "stop", ErrAliasShadowsBuiltin},
+		{"shadows keyword prefix", "pause", "skip it", ErrAliasShadowsBuiltin},
+		{"shadows play", "stop", "play something", ErrAliasShadowsBuiltin},
+		{"shadows volume", "mute", "volume 0", ErrAliasShadowsBuiltin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.AddAlias(tt.command, tt.phrase); !errors.Is(err, tt.want) {
+				t.Errorf("AddAlias(%q, %q) error = %v, want %v", tt.command, tt.phrase, err, tt.want)
+			}
+		})
+	}
+
+	if err := svc.AddAlias("stop", "  "); err == nil {
+		t.Error("AddAlias with an empty phrase succeeded")
+	}
+	if got := parse(t, svc, "laser stop"); got != "!stop" {
+		t.Errorf("rejected alias changed a built-in: parse = %q", got)
+	}
+}
+
+func TestAddAliasOverride(t *testing.T) {
+	svc := newTestService()
+	if err := svc.AddAliasOverride("pause", "stop"); err != nil {
+		t.Fatalf("AddAliasOverride error: %v", err)
+	}
+
+	if got := parse(t, svc, "laser stop"); got != "!pause" {
+		t.Errorf("parse = %q, want the override %q", got, "!pause")
+	}
+	if got := parse(t, svc, "laser skip"); got != "!skip" {
+		t.Errorf("parse = %q, want other built-ins untouched", got)
+	}
+}
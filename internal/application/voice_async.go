@@ -0,0 +1,23 @@
+package application
+
+import "context"
+
+// AsyncVoiceResult is the outcome delivered by HandleVoiceAsync.
+type AsyncVoiceResult struct {
+	VoiceResult
+	// Err is the error HandleVoiceDetailed returned, e.g. ctx.Err() on cancellation.
+	Err error
+}
+
+// HandleVoiceAsync runs HandleVoiceDetailed in a new goroutine and delivers its
+// single result on the returned channel, which is then closed. The channel is
+// buffered, so the goroutine finishes even if the result is never read.
+func (s *VoiceService) HandleVoiceAsync(ctx context.Context, channelID, userID string, audioWAV []byte) <-chan AsyncVoiceResult {
+	results := make(chan AsyncVoiceResult, 1)
+	go func() {
+		defer close(results)
+		result, err := s.HandleVoiceDetailed(ctx, channelID, userID, audioWAV)
+		results <- AsyncVoiceResult{VoiceResult: result, Err: err}
+	}()
+	return results
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// drain collects everything sent on the channel until it is closed.
+func drain(results <-chan AsyncVoiceResult) []AsyncVoiceResult {
+	var all []AsyncVoiceResult
+	for r := range results {
+		all = append(all, r)
+	}
+	return all
+}
+
+func TestHandleVoiceAsync(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{text: "laser stop"}, "laser", nil, nil)
+
+	all := drain(svc.HandleVoiceAsync(context.Background(), "ch1", "u1", []byte("fake-audio")))
+	if len(all) != 1 {
+		t.Fatalf("got %d results, want 1", len(all))
+	}
+	want := VoiceResult{Transcription: "laser stop", Command: "!stop", Outcome: OutcomeMatched, Confirmation: "Stopping playback"}
+	if all[0].Err != nil || all[0].VoiceResult != want {
+		t.Errorf("result = %+v, want %+v", all[0], want)
+	}
+}
+
+func TestHandleVoiceAsync_Error(t *testing.T) {
+	sttErr := errors.New("stt down")
+	svc := NewVoiceService(&mockSTT{err: sttErr}, "laser", nil, nil)
+
+	all := drain(svc.HandleVoiceAsync(context.Background(), "ch1", "u1", []byte("fake-audio")))
+	if len(all) != 1 || !errors.Is(all[0].Err, sttErr) {
+		t.Errorf("results = %+v, want one carrying %v", all, sttErr)
+	}
+}
+
+func TestHandleVoiceAsync_Cancelled(t *testing.T) {
+	stt := &blockingSTT{started: make(chan struct{})}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := svc.HandleVoiceAsync(ctx, "ch1", "u1", []byte("fake-audio"))
+	<-stt.started
+	cancel()
+
+	all := drain(results)
+	if len(all) != 1 {
+		t.Fatalf("got %d results, want 1", len(all))
+	}
+	if !errors.Is(all[0].Err, context.Canceled) {
+		t.Errorf("Err = %v, want %v", all[0].Err, context.Canceled)
+	}
+	if all[0].Command != "" {
+		t.Errorf("Command = %q, want empty", all[0].Command)
+	}
+}
@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// DefaultAudioFormat is the format HandleVoice assumes: 16-bit PCM WAV at
+// 48 kHz stereo, as produced by the Discord voice listener.
+var DefaultAudioFormat = bot.AudioFormat{SampleRate: 48000, Channels: 2, Encoding: "wav"}
+
+// HandleVoiceWithFormat is like HandleVoice for audio in the given format. The
+// format is passed to STT services that implement bot.FormatSTTService; others
+// receive the bytes alone. An audio preprocessor, if set, must leave the audio
+// in this format.
+func (s *VoiceService) HandleVoiceWithFormat(ctx context.Context, channelID, userID string, audio []byte, format bot.AudioFormat) (string, error) {
+	result, err := s.handleVoice(ctx, channelID, userID, audio, format)
+	return result.Command, err
+}
+
+// transcribeWith passes the audio to an STT service, along with its format if
+// the service accepts one.
+func transcribeWith(ctx context.Context, stt bot.STTService, audio []byte, format bot.AudioFormat) (string, error) {
+	if fs, ok := stt.(bot.FormatSTTService); ok {
+		return fs.TranscribeFormat(ctx, audio, format)
+	}
+	return stt.Transcribe(ctx, audio)
+}
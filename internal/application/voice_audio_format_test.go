@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// formatSTT is a format-aware STT that records the format it was given.
+type formatSTT struct {
+	text        string
+	format      bot.AudioFormat
+	formatCalls int
+	plainCalls  int
+}
+
+func (m *formatSTT) Transcribe(_ context.Context, _ []byte) (string, error) {
+	m.plainCalls++
+	return m.text, nil
+}
+
+func (m *formatSTT) TranscribeFormat(_ context.Context, _ []byte, format bot.AudioFormat) (string, error) {
+	m.formatCalls++
+	m.format = format
+	return m.text, nil
+}
+
+func TestHandleVoiceWithFormat_ForwardsFormat(t *testing.T) {
+	stt := &formatSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	format := bot.AudioFormat{SampleRate: 16000, Channels: 1, Encoding: "opus"}
+
+	got, err := svc.HandleVoiceWithFormat(context.Background(), "ch1", "u1", []byte("fake-audio"), format)
+	if err != nil {
+		t.Fatalf("HandleVoiceWithFormat error: %v", err)
+	}
+	if got != "!stop" {
+		t.Errorf("HandleVoiceWithFormat = %q, want %q", got, "!stop")
+	}
+	if stt.formatCalls != 1 || stt.plainCalls != 0 {
+		t.Fatalf("TranscribeFormat called %d times and Transcribe %d times, want 1 and 0",
+			stt.formatCalls, stt.plainCalls)
+	}
+	if stt.format != format {
+		t.Errorf("format = %+v, want %+v", stt.format, format)
+	}
+}
+
+func TestHandleVoice_DefaultFormat(t *testing.T) {
+	stt := &formatSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	if _, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio")); err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	want := bot.AudioFormat{SampleRate: 48000, Channels: 2, Encoding: "wav"}
+	if stt.format != want {
+		t.Errorf("format = %+v, want %+v", stt.format, want)
+	}
+}
+
+func TestHandleVoiceWithFormat_PlainSTT(t *testing.T) {
+	stt := &mockSTT{text: "laser skip"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	got, err := svc.HandleVoiceWithFormat(context.Background(), "ch1", "u1", []byte("fake-audio"), DefaultAudioFormat)
+	if err != nil {
+		t.Fatalf("HandleVoiceWithFormat error: %v", err)
+	}
+	if got != "!skip" || stt.calls != 1 {
+		t.Errorf("HandleVoiceWithFormat = %q after %d Transcribe calls, want %q after 1", got, stt.calls, "!skip")
+	}
+}
@@ -0,0 +1,21 @@
+package application
+
+import "strings"
+
+// SetBareWakeCommand sets the command sent when the wake phrase is said with
+// no command after it, as in "laser" or "hey laser", e.g. "listen" for
+// "!listen". It takes precedence over wake buffering, so a bare wake phrase is
+// sent at once rather than joined with the next clip. An empty name (the
+// default) keeps a bare wake phrase a no-op.
+func (s *VoiceService) SetBareWakeCommand(name string) {
+	s.bareWake = strings.ToLower(strings.TrimSpace(name))
+}
+
+// bareWakeTrace resolves a bare wake phrase to the configured command, or
+// returns false if there is none.
+func (s *VoiceService) bareWakeTrace() (CommandTrace, bool) {
+	if s.bareWake == "" {
+		return CommandTrace{}, false
+	}
+	return CommandTrace{Branch: BranchKeyword, Command: s.command(s.bareWake)}, true
+}
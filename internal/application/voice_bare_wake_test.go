@@ -0,0 +1,54 @@
+package application
+
+import "testing"
+
+func TestBareWakeCommand(t *testing.T) {
+	svc := newTestService()
+	svc.SetBareWakeCommand("listen")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser", "!listen"},
+		{"Laser.", "!listen"},
+		{"hey laser", "!listen"},
+		{"laser, um", "!listen"},
+		{"laser stop", "!stop"},
+		{"hello there", ""},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestBareWakeCommand_DefaultIsNoOp(t *testing.T) {
+	svc := newTestService()
+
+	for _, input := range []string{"laser", "hey laser"} {
+		if got := parse(t, svc, input); got != "" {
+			t.Errorf("parse(%q) = %q, want no command", input, got)
+		}
+	}
+
+	svc.SetBareWakeCommand("listen")
+	svc.SetBareWakeCommand("")
+	if got := parse(t, svc, "laser"); got != "" {
+		t.Errorf("after clearing, parse(%q) = %q, want no command", "laser", got)
+	}
+}
+
+func TestBareWakeCommand_SentInsteadOfBuffering(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newWakeBufferService(stt)
+	svc.SetBareWakeCommand("listen")
+
+	if got := handle(t, svc, stt, "u1", "laser"); got != "!listen" {
+		t.Fatalf("bare wake = %q, want %q", got, "!listen")
+	}
+	if got := handle(t, svc, stt, "u1", "stop"); got != "" {
+		t.Errorf("follow-up = %q, want no command since nothing was buffered", got)
+	}
+}
@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// blockingSTT signals started and then blocks until ctx is done.
+type blockingSTT struct {
+	started chan struct{}
+}
+
+func (m *blockingSTT) Transcribe(ctx context.Context, _ []byte) (string, error) {
+	close(m.started)
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+// blockingLLM signals started and then blocks until ctx is done.
+type blockingLLM struct {
+	started chan struct{}
+	calls   int
+}
+
+func (m *blockingLLM) ChatCompletion(ctx context.Context, _ []bot.LLMMessage) (string, error) {
+	m.calls++
+	close(m.started)
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestHandleVoice_AlreadyCancelled(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	got, err := svc.HandleVoice(ctx, "ch1", "u1", []byte("fake-audio"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("HandleVoice error = %v, want %v", err, context.Canceled)
+	}
+	if got != "" {
+		t.Errorf("HandleVoice = %q, want empty", got)
+	}
+	if stt.calls != 0 {
+		t.Errorf("Transcribe called %d times with a cancelled context, want 0", stt.calls)
+	}
+}
+
+func TestHandleVoice_CancelledDuringTranscribe(t *testing.T) {
+	stt := &blockingSTT{started: make(chan struct{})}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stt.started
+		cancel()
+	}()
+
+	_, err := svc.HandleVoice(ctx, "ch1", "u1", []byte("fake-audio"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("HandleVoice error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestHandleVoice_CancelledDuringLLM(t *testing.T) {
+	llm := &blockingLLM{started: make(chan struct{})}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(&mockSTT{text: "laser play its working"}, "laser", llm, opts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-llm.started
+		cancel()
+	}()
+
+	got, err := svc.HandleVoice(ctx, "ch1", "u1", []byte("fake-audio"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("HandleVoice error = %v, want %v", err, context.Canceled)
+	}
+	if got != "" {
+		t.Errorf("HandleVoice = %q, want no command after cancellation", got)
+	}
+	if llm.calls != 1 {
+		t.Errorf("ChatCompletion called %d times, want 1", llm.calls)
+	}
+	if stats := svc.Stats(); stats.Transcriptions != 0 {
+		t.Errorf("cancelled call was counted: %+v", stats)
+	}
+}
+
+// ctxCheckingSTT records whether it received the caller's context.
+type ctxCheckingSTT struct {
+	key  any
+	seen bool
+}
+
+func (m *ctxCheckingSTT) Transcribe(ctx context.Context, _ []byte) (string, error) {
+	m.seen = ctx.Value(m.key) != nil
+	return "laser play its working", nil
+}
+
+type ctxCheckingLLM struct {
+	key  any
+	seen bool
+}
+
+func (m *ctxCheckingLLM) ChatCompletion(ctx context.Context, _ []bot.LLMMessage) (string, error) {
+	m.seen = ctx.Value(m.key) != nil
+	return "itsworking", nil
+}
+
+func TestHandleVoice_ForwardsContext(t *testing.T) {
+	type ctxKey struct{}
+	stt := &ctxCheckingSTT{key: ctxKey{}}
+	llm := &ctxCheckingLLM{key: ctxKey{}}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(stt, "laser", llm, opts)
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, true)
+	if _, err := svc.HandleVoice(ctx, "ch1", "u1", []byte("fake-audio")); err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if !stt.seen {
+		t.Error("Transcribe did not receive the caller's context")
+	}
+	if !llm.seen {
+		t.Error("ChatCompletion did not receive the caller's context")
+	}
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"slices"
+)
+
+// Scores of the readings ParseCandidates offers besides the parsed command. A
+// play query mentioning "random" reads as a random request before it reads as
+// a literal search.
+const (
+	randomReadingScore = 0.6
+	playReadingScore   = 0.4
+)
+
+// Candidate is one interpretation of a transcription returned by ParseCandidates.
+type Candidate struct {
+	// Command is the command this interpretation would send.
+	Command VoiceCommand
+	// Branch is the parsing branch that produced Command.
+	Branch MatchBranch
+	// Score ranks the interpretation from 0 to 1. The command HandleVoice would
+	// send scores 1.
+	Score float64
+}
+
+// ParseCandidates returns the plausible interpretations of a transcription,
+// best first, for a handler that wants to ask the user when the words could
+// mean more than one thing: "laser play random songs by Daft Punk" is a random
+// request filtered to Daft Punk, but could be a search for that title. The
+// first candidate is the command Explain returns. Like Explain, it sends
+// nothing and leaves counters and per-user state untouched. Returns nil if no
+// command would be produced.
+func (s *VoiceService) ParseCandidates(ctx context.Context, transcription string) []Candidate {
+	trace, ok := s.explainMatched(withDryRun(ctx), transcription)
+	if !ok {
+		return nil
+	}
+
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	candidates := []Candidate{{Command: trace.Command, Branch: trace.Branch, Score: 1}}
+	for _, alt := range s.alternativeReadings(trace) {
+		seen := slices.ContainsFunc(candidates, func(c Candidate) bool { return c.Command.Text == alt.Command.Text })
+		if !seen && !s.commandDisabled(alt.Command.Name) {
+			candidates = append(candidates, alt)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b Candidate) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	return candidates
+}
+
+// alternativeReadings returns the other ways the words behind trace can be
+// read: as a random request and as a literal play query.
+func (s *VoiceService) alternativeReadings(trace CommandTrace) []Candidate {
+	cw, shuffle, ok := s.tracedWords(trace)
+	if !ok {
+		return nil
+	}
+	verbLen, isPlay := playVerbLength(cw.words)
+	if !isPlay || len(cw.words) == verbLen {
+		return nil
+	}
+	args, shuffled := stripShuffle(cw.slice(verbLen, len(cw.words)))
+	shuffle = shuffle || shuffled
+
+	var readings []Candidate
+	if slices.Contains(args.words, "random") {
+		readings = append(readings, Candidate{
+			Command: s.command("pr", randomFilters(s.trimPoliteness(args))...),
+			Branch:  BranchKeyword,
+			Score:   randomReadingScore,
+		})
+	}
+	if query, ok := s.limitQuery(s.spokenQuery(args)); ok && query != "" {
+		readings = append(readings, Candidate{
+			Command: s.playCommand("play", query, shuffle),
+			Branch:  BranchPassthrough,
+			Score:   playReadingScore,
+		})
+	}
+	return readings
+}
+
+// tracedWords finds the command words trace was parsed from, along with
+// whether a leading "shuffle" was stripped from them.
+func (s *VoiceService) tracedWords(trace CommandTrace) (commandWords, bool, bool) {
+	remainder, _, ok := s.commandText(s.wakePhrase, trace.Transcription)
+	if !ok {
+		return commandWords{}, false, false
+	}
+	for _, segment := range s.splitCompound(s.dropNoise(remainder)) {
+		segment, _ = s.replaceHomophone(segment)
+		if cw, shuffle := leadingShuffle(segment); cw.text() == trace.Remainder {
+			return cw, shuffle, true
+		}
+	}
+	return commandWords{}, false, false
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestParseCandidates_Ambiguous(t *testing.T) {
+	svc := newTestService()
+
+	got := svc.ParseCandidates(context.Background(), "laser play random songs by Daft Punk")
+	want := []string{"!pr Daft Punk", "!play random songs by Daft Punk"}
+	if len(got) != len(want) {
+		t.Fatalf("ParseCandidates = %+v, want %d candidates", got, len(want))
+	}
+	for i, c := range got {
+		if c.Command.Text != want[i] {
+			t.Errorf("candidate %d = %q, want %q", i, c.Command.Text, want[i])
+		}
+	}
+	if got[0].Score != 1 || got[1].Score >= got[0].Score {
+		t.Errorf("scores = %v, %v; want 1 then lower", got[0].Score, got[1].Score)
+	}
+	if trace, _ := svc.Explain(context.Background(), "laser play random songs by Daft Punk"); trace.Command.Text != got[0].Command.Text {
+		t.Errorf("Explain = %q, want the top candidate %q", trace.Command.Text, got[0].Command.Text)
+	}
+}
+
+func TestParseCandidates_Homophone(t *testing.T) {
+	svc := newTestService()
+
+	got := svc.ParseCandidates(context.Background(), "laser plate random songs by Daft Punk")
+	if len(got) != 2 || got[1].Command.Text != "!play random songs by Daft Punk" {
+		t.Errorf("ParseCandidates = %+v, want the literal reading after the random one", got)
+	}
+}
+
+func TestParseCandidates_MatchedQueryOffersLiteral(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Daft Punk"}, {Name: "Justice"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: "Daft Punk"}, opts)
+
+	got := svc.ParseCandidates(context.Background(), "laser play the french robots")
+	if len(got) != 2 {
+		t.Fatalf("ParseCandidates = %+v, want the matched option and the literal query", got)
+	}
+	if got[0].Branch != BranchLLM || got[1].Command.Text != "!play the french robots" || got[1].Branch != BranchPassthrough {
+		t.Errorf("ParseCandidates = %+v, want the LLM match then the literal query", got)
+	}
+}
+
+func TestParseCandidates_Unambiguous(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser stop", "!stop"},
+		{"laser play jazz", "!play jazz"},
+		{"laser surprise me", "!pr"},
+	}
+	for _, tt := range tests {
+		got := svc.ParseCandidates(context.Background(), tt.input)
+		if len(got) != 1 || got[0].Command.Text != tt.want {
+			t.Errorf("ParseCandidates(%q) = %+v, want only %q", tt.input, got, tt.want)
+		}
+	}
+	if got := svc.ParseCandidates(context.Background(), "play jazz"); got != nil {
+		t.Errorf("ParseCandidates without wake phrase = %+v, want nil", got)
+	}
+}
+
+func TestParseCandidates_SkipsDisabled(t *testing.T) {
+	svc := newTestService()
+	svc.SetCommandEnabled("play", false)
+
+	got := svc.ParseCandidates(context.Background(), "laser play something random")
+	if len(got) != 1 || got[0].Command.Name != "pr" {
+		t.Errorf("ParseCandidates = %+v, want only the random request", got)
+	}
+}
@@ -0,0 +1,34 @@
+package application
+
+// SetChannelWakePhrase overrides the wake phrase for one channel, e.g. "buddy"
+// in a kids' channel while others keep the global phrase. The phrase is
+// checked like SetWakePhrase's, and an invalid one leaves the channel as it
+// was; use RemoveChannelWakePhrase to drop the override. Alternate spellings
+// registered with SetWakeAlternates apply to the override too. A wake regexp,
+// if set, takes precedence.
+func (s *VoiceService) SetChannelWakePhrase(channelID, phrase string) error {
+	if err := ValidateWakePhrase(phrase); err != nil {
+		return err
+	}
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	s.channelWake[channelID] = normalizeWakePhrase(phrase)
+	return nil
+}
+
+// RemoveChannelWakePhrase drops the channel's wake phrase override, so it uses
+// the global phrase again.
+func (s *VoiceService) RemoveChannelWakePhrase(channelID string) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	delete(s.channelWake, channelID)
+}
+
+// wakePhraseFor returns the wake phrase for the channel, falling back to the
+// global phrase.
+func (s *VoiceService) wakePhraseFor(channelID string) string {
+	if phrase, ok := s.channelWake[channelID]; ok {
+		return phrase
+	}
+	return s.wakePhrase
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestChannelWakePhrase(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetChannelWakePhrase("kids", " Buddy ")
+
+	tests := []struct {
+		name    string
+		channel string
+		text    string
+		want    string
+	}{
+		{"override in its channel", "kids", "hey buddy stop", "!stop"},
+		{"global phrase in overridden channel", "kids", "laser stop", ""},
+		{"global phrase elsewhere", "main", "laser stop", "!stop"},
+		{"override elsewhere", "main", "buddy stop", ""},
+		{"alternate of global phrase elsewhere", "main", "lazer skip", "!skip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stt.text = tt.text
+			got, err := svc.HandleVoice(context.Background(), tt.channel, "u1", []byte("fake-audio"))
+			if err != nil {
+				t.Fatalf("HandleVoice error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HandleVoice(%s, %q) = %q, want %q", tt.channel, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelWakePhrase_Alternates(t *testing.T) {
+	stt := &mockSTT{text: "budy pause"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetChannelWakePhrase("kids", "buddy")
+	svc.SetWakeAlternates("buddy", []string{"budy"})
+
+	got, err := svc.HandleVoice(context.Background(), "kids", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "!pause" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!pause")
+	}
+}
+
+func TestChannelWakePhrase_Remove(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	if err := svc.SetChannelWakePhrase("kids", "buddy"); err != nil {
+		t.Fatalf("SetChannelWakePhrase: %v", err)
+	}
+	svc.RemoveChannelWakePhrase("kids")
+
+	got, err := svc.HandleVoice(context.Background(), "kids", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "!stop" {
+		t.Errorf("HandleVoice = %q, want %q after removing the override", got, "!stop")
+	}
+}
+
+func TestChannelWakePhrase_Invalid(t *testing.T) {
+	stt := &mockSTT{text: "buddy stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	if err := svc.SetChannelWakePhrase("kids", "buddy"); err != nil {
+		t.Fatalf("SetChannelWakePhrase: %v", err)
+	}
+
+	tests := []struct {
+		phrase string
+		want   error
+	}{
+		{"", ErrEmptyWakePhrase},
+		{"   ", ErrEmptyWakePhrase},
+		{"!!!", ErrInvalidWakePhrase},
+	}
+	for _, tt := range tests {
+		if err := svc.SetChannelWakePhrase("kids", tt.phrase); !errors.Is(err, tt.want) {
+			t.Errorf("SetChannelWakePhrase(%q) error = %v, want %v", tt.phrase, err, tt.want)
+		}
+	}
+
+	got, err := svc.HandleVoice(context.Background(), "kids", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "!stop" {
+		t.Errorf("HandleVoice = %q, want %q with the override kept", got, "!stop")
+	}
+}
+
+func TestChannelWakePhrase_ConfirmFollowUp(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetChannelWakePhrase("kids", "buddy")
+	svc.SetConfirmCommands([]string{"clear"})
+
+	trace, ok := svc.explain(context.Background(), "kids", "buddy clear the queue")
+	if !ok {
+		t.Fatal("explain returned no match")
+	}
+	if trace.Command.FollowUp != "buddy confirm" {
+		t.Errorf("FollowUp = %q, want %q", trace.Command.FollowUp, "buddy confirm")
+	}
+}
@@ -0,0 +1,23 @@
+package application
+
+import "time"
+
+// Clock tells the time. VoiceService uses it for confirmation timeouts and wake
+// buffering, so tests can control time instead of sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the wall clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// SetClock replaces the clock used for time-dependent behavior. A nil clock
+// restores the wall clock.
+func (s *VoiceService) SetClock(c Clock) {
+	if c == nil {
+		c = realClock{}
+	}
+	s.clock = c
+}
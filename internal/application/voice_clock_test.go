@@ -0,0 +1,60 @@
+package application
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when advanced.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func TestClock_ConfirmationExpiry(t *testing.T) {
+	stt := &mockSTT{}
+	clock := newFakeClock()
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetClock(clock)
+	svc.SetConfirmCommands([]string{"clear"})
+	svc.SetConfirmTimeout(time.Minute)
+
+	handle(t, svc, stt, "u1", "laser clear the queue")
+	clock.Advance(59 * time.Second)
+	if got := handle(t, svc, stt, "u1", "laser confirm"); got != "!clear" {
+		t.Errorf("confirm before expiry = %q, want %q", got, "!clear")
+	}
+
+	handle(t, svc, stt, "u1", "laser clear the queue")
+	clock.Advance(time.Minute)
+	if got := handle(t, svc, stt, "u1", "laser confirm"); got != "" {
+		t.Errorf("confirm at expiry = %q, want nothing", got)
+	}
+}
+
+func TestClock_NilRestoresWallClock(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, nil)
+	svc.SetClock(newFakeClock())
+	svc.SetClock(nil)
+
+	if since := time.Since(svc.clock.Now()); since < 0 || since > time.Minute {
+		t.Errorf("clock after SetClock(nil) is %v off the wall clock", since)
+	}
+}
@@ -0,0 +1,46 @@
+package application
+
+import "strconv"
+
+// commandArg describes one structured argument of a command, in the order it
+// is rendered into the command text.
+type commandArg struct {
+	key  string
+	flag string // rendered instead of the value when the argument is "true"
+}
+
+// commandArgs lists the structured arguments of the commands that take them.
+// Commands missing here render their arguments positionally with command.
+var commandArgs = map[string][]commandArg{
+	// "level" is an absolute 0–100 level, "change" a signed step like "+10".
+	"volume":    {{key: "level"}, {key: "change"}},
+	seekCommand: {{key: "position"}},
+	"play":      {{key: "query"}, {key: "shuffle", flag: shuffleFlag}},
+	"playlist":  {{key: "query"}, {key: "shuffle", flag: shuffleFlag}},
+	"search":    {{key: "query"}},
+	// "number" is the 1-based entry of the user's last results.
+	resultCommand: {{key: "number"}, {key: "shuffle", flag: shuffleFlag}},
+}
+
+// commandWith builds the named command from structured arguments, rendering
+// its text from them so handlers can read Args instead of re-parsing Text.
+// Arguments that are empty, or a flag that isn't "true", are left out.
+func (s *VoiceService) commandWith(name string, args map[string]string) VoiceCommand {
+	var parts []string
+	for _, arg := range commandArgs[name] {
+		value := args[arg.key]
+		switch {
+		case value == "":
+			continue
+		case arg.flag != "":
+			if b, _ := strconv.ParseBool(value); !b {
+				continue
+			}
+			value = arg.flag
+		}
+		parts = append(parts, value)
+	}
+	cmd := s.command(name, parts...)
+	cmd.Args = args
+	return cmd
+}
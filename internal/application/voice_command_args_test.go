@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+	"maps"
+	"testing"
+)
+
+func TestCommandArgs(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		input    string
+		wantName string
+		wantArgs map[string]string
+		wantText string
+	}{
+		{"laser volume fifty", "volume", map[string]string{"level": "50"}, "!volume 50"},
+		{"laser turn it up", "volume", map[string]string{"change": "+10"}, "!volume +10"},
+		{"laser skip to 1:30", "seek", map[string]string{"position": "90"}, "!seek 90"},
+		{"laser seek to two minutes", "seek", map[string]string{"position": "120"}, "!seek 120"},
+		{"laser play jazz on shuffle", "play", map[string]string{"query": "jazz", "shuffle": "true"}, "!play jazz --shuffle"},
+		{"laser stop", "stop", nil, "!stop"},
+	}
+
+	for _, tt := range tests {
+		trace, ok := svc.Explain(context.Background(), tt.input)
+		cmd := trace.Command
+		if !ok || cmd.Name != tt.wantName || cmd.Text != tt.wantText || !maps.Equal(cmd.Args, tt.wantArgs) {
+			t.Errorf("Explain(%q) = (%q, %v, %q), want (%q, %v, %q)",
+				tt.input, cmd.Name, cmd.Args, cmd.Text, tt.wantName, tt.wantArgs, tt.wantText)
+		}
+	}
+}
+
+func TestCommandArgs_RenderUsesPrefix(t *testing.T) {
+	svc := newTestService()
+	svc.SetCommandPrefix("/")
+
+	cmd := svc.commandWith("volume", map[string]string{"level": "20"})
+	if cmd.Text != "/volume 20" || cmd.Args["level"] != "20" {
+		t.Errorf("commandWith = (%q, %v), want (%q, level 20)", cmd.Text, cmd.Args, "/volume 20")
+	}
+	if cmd := svc.commandWith("play", map[string]string{"query": "jazz", "shuffle": "false"}); cmd.Text != "/play jazz" {
+		t.Errorf("commandWith without shuffle = %q, want %q", cmd.Text, "/play jazz")
+	}
+}
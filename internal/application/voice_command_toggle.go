@@ -0,0 +1,21 @@
+package application
+
+import "strings"
+
+// SetCommandEnabled enables or disables a command by name (e.g. "play", "pr",
+// "playlist", "stop"), for channels where some commands should only be given
+// by text. A disabled command is still recognized but refused with
+// RejectDisabled, and "again" won't repeat it. All commands start enabled.
+func (s *VoiceService) SetCommandEnabled(name string, enabled bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if enabled {
+		delete(s.disabled, name)
+		return
+	}
+	s.disabled[name] = true
+}
+
+// commandDisabled reports whether the named command has been disabled.
+func (s *VoiceService) commandDisabled(name string) bool {
+	return s.disabled[name]
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestSetCommandEnabled(t *testing.T) {
+	llm := &mockLLM{reply: "Jazz Classics"}
+	options := &mockPlayOptions{options: []bot.PlayOption{{Name: "Jazz Classics"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, options)
+	svc.SetCommandEnabled("Play", false)
+
+	if got := parse(t, svc, "laser play some jazz"); got != "" {
+		t.Errorf("disabled play = %q, want no command", got)
+	}
+	if len(llm.messages) != 0 {
+		t.Errorf("LLM called for a disabled play command")
+	}
+	trace, ok := svc.Explain(context.Background(), "laser put on some jazz")
+	if ok || trace.Reason != RejectDisabled || trace.Command.Name != "play" {
+		t.Errorf("Explain = %+v (ok=%v), want play refused with %q", trace, ok, RejectDisabled)
+	}
+
+	for input, want := range map[string]string{
+		"laser stop":                   "!stop",
+		"laser skip":                   "!skip",
+		"laser play random":            "!pr",
+		"laser play my chill playlist": "!playlist chill",
+	} {
+		if got := parse(t, svc, input); got != want {
+			t.Errorf("parse(%q) with play disabled = %q, want %q", input, got, want)
+		}
+	}
+
+	svc.SetCommandEnabled("play", true)
+	if got := parse(t, svc, "laser play some jazz"); got != "!play Jazz Classics" {
+		t.Errorf("re-enabled play = %q, want %q", got, "!play Jazz Classics")
+	}
+}
+
+func TestSetCommandEnabled_Compound(t *testing.T) {
+	svc := newTestService()
+	svc.SetCommandEnabled("stop", false)
+
+	cmds := svc.ParseCommands(context.Background(), "laser stop and play random")
+	if len(cmds) != 1 || cmds[0].Text != "!pr" {
+		t.Errorf("ParseCommands = %+v, want only !pr", cmds)
+	}
+}
+
+func TestSetCommandEnabled_Again(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	handle(t, svc, stt, "u1", "laser play jazz")
+	svc.SetCommandEnabled("play", false)
+	if got := handle(t, svc, stt, "u1", "laser again"); got != "" {
+		t.Errorf("again after disabling play = %q, want no command", got)
+	}
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"slices"
+	"time"
+)
+
+// VoiceConfig holds the VoiceService settings that can be changed at runtime
+// with ApplyConfig, e.g. after a config file is reloaded.
+type VoiceConfig struct {
+	// WakePhrase is the phrase commands must start with. Must not be empty.
+	WakePhrase string
+	// RequireWakePhrase controls whether commands must start with the wake phrase.
+	RequireWakePhrase bool
+	// CommandPrefix is prepended to command output (e.g. "!").
+	CommandPrefix string
+	// CommandFillers are the words allowed between the wake phrase and the command.
+	CommandFillers []string
+	// StrictWakeAdjacency allows only CommandFillers before the command.
+	StrictWakeAdjacency bool
+	// MaxInterveningWords is how many non-filler words may precede the command
+	// in non-strict mode.
+	MaxInterveningWords int
+	// MinAudioBytes skips transcription of shorter audio. Zero disables the check.
+	MinAudioBytes int
+	// MaxQueryLength limits play queries to this many characters. Zero is unlimited.
+	MaxQueryLength int
+	// VolumeStep is how far "louder" and "quieter" move the volume. Zero or
+	// less uses the default.
+	VolumeStep int
+	// ConfirmTimeout is how long a pending confirmation waits for the follow-up.
+	ConfirmTimeout time.Duration
+	// WakeBufferWindow is how long a bare wake phrase waits for the rest of the
+	// command. Zero disables buffering.
+	WakeBufferWindow time.Duration
+	// FuzzyKeywords accepts command keywords one typo away.
+	FuzzyKeywords bool
+	// WakeSensitivity is how many letters the wake word may be off by, from 0
+	// to MaxWakeSensitivity.
+	WakeSensitivity int
+	// LocalMatching fuzzy-matches play options locally when the LLM is unavailable.
+	LocalMatching bool
+	// PhoneticMatching picks the play option that sounds like the query before
+	// asking the LLM.
+	PhoneticMatching bool
+}
+
+// Config returns the service's current runtime settings, for callers that want
+// to change a few of them with ApplyConfig.
+func (s *VoiceService) Config() VoiceConfig {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+
+	fillers := make([]string, 0, len(s.commandFillers))
+	for w := range s.commandFillers {
+		fillers = append(fillers, w)
+	}
+	slices.Sort(fillers)
+
+	return VoiceConfig{
+		WakePhrase:          s.wakePhrase,
+		RequireWakePhrase:   s.requireWake,
+		CommandPrefix:       s.commandPrefix,
+		CommandFillers:      fillers,
+		StrictWakeAdjacency: s.strictAdjacency,
+		MaxInterveningWords: s.maxIntervening,
+		MinAudioBytes:       s.minAudio,
+		MaxQueryLength:      s.maxQuery,
+		VolumeStep:          s.volumeStep,
+		ConfirmTimeout:      s.confirmTimeout,
+		WakeBufferWindow:    s.wakeWindow,
+		FuzzyKeywords:       s.fuzzyKeywords,
+		WakeSensitivity:     s.wakeSensitivity,
+		LocalMatching:       s.localMatch,
+		PhoneticMatching:    s.phonetic,
+	}
+}
+
+// ApplyConfig replaces the runtime settings in one step. It is safe to call
+// while voice clips are being handled, and doesn't wait for play queries being
+// matched: clips already being parsed finish with the old settings and later
+// ones use the new, except that a command whose play query was still being
+// matched is built with the new. Pending confirmations, buffered
+// wake phrases, command history and stats are kept; a command already held for
+// confirmation is sent as it was parsed. The config is rejected without
+// changing anything if its wake phrase is empty.
+func (s *VoiceService) ApplyConfig(cfg VoiceConfig) error {
+	if err := ValidateWakePhrase(cfg.WakePhrase); err != nil {
+		return err
+	}
+
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	s.wakePhrase = normalizeWakePhrase(cfg.WakePhrase)
+	s.requireWake = cfg.RequireWakePhrase
+	s.commandPrefix = cfg.CommandPrefix
+	s.commandFillers = wordSet(cfg.CommandFillers)
+	s.strictAdjacency = cfg.StrictWakeAdjacency
+	s.maxIntervening = cfg.MaxInterveningWords
+	s.minAudio = cfg.MinAudioBytes
+	s.maxQuery = cfg.MaxQueryLength
+	s.SetVolumeStep(cfg.VolumeStep)
+	s.confirmTimeout = cfg.ConfirmTimeout
+	s.wakeWindow = cfg.WakeBufferWindow
+	s.fuzzyKeywords = cfg.FuzzyKeywords
+	s.wakeSensitivity = clampWakeSensitivity(cfg.WakeSensitivity)
+	s.localMatch = cfg.LocalMatching
+	s.phonetic = cfg.PhoneticMatching
+	return nil
+}
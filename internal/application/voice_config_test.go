@@ -0,0 +1,204 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// staticSTT always returns the same transcription, and is safe for concurrent use.
+type staticSTT string
+
+func (s staticSTT) Transcribe(_ context.Context, _ []byte) (string, error) {
+	return string(s), nil
+}
+
+func TestApplyConfig(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetConfirmCommands([]string{"clear"})
+
+	if got := handle(t, svc, stt, "u1", "laser stop"); got != "!stop" {
+		t.Fatalf("before reload = %q, want %q", got, "!stop")
+	}
+	handle(t, svc, stt, "u1", "laser clear the queue")
+
+	cfg := svc.Config()
+	cfg.WakePhrase = "Jarvis"
+	cfg.CommandPrefix = "/"
+	cfg.CommandFillers = []string{"bitte"}
+	cfg.StrictWakeAdjacency = true
+	if err := svc.ApplyConfig(cfg); err != nil {
+		t.Fatalf("ApplyConfig error: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser stop", ""},
+		{"jarvis stop", "/stop"},
+		{"jarvis bitte skip", "/skip"},
+		{"jarvis hmm skip", ""},
+		{"jarvis could you skip", ""},
+	}
+	for _, tt := range tests {
+		if got := handle(t, svc, stt, "u2", tt.input); got != tt.want {
+			t.Errorf("after reload, %q = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	// The command held before the reload is still pending for u1.
+	if got := handle(t, svc, stt, "u1", "jarvis confirm"); got != "!clear" {
+		t.Errorf("confirm after reload = %q, want the held %q", got, "!clear")
+	}
+	if got := svc.Stats().Commands["stop"]; got != 2 {
+		t.Errorf("stop count after reload = %d, want 2", got)
+	}
+}
+
+func TestApplyConfig_RoundTrip(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, nil)
+	svc.SetCommandFillers([]string{"um", "Please"})
+	svc.SetMaxQueryLength(40)
+
+	cfg := svc.Config()
+	if want := []string{"please", "um"}; !slices.Equal(cfg.CommandFillers, want) {
+		t.Errorf("Config().CommandFillers = %v, want %v", cfg.CommandFillers, want)
+	}
+	if err := svc.ApplyConfig(cfg); err != nil {
+		t.Fatalf("ApplyConfig error: %v", err)
+	}
+	if got := svc.Config(); !slices.Equal(got.CommandFillers, cfg.CommandFillers) || got.MaxQueryLength != 40 || got.WakePhrase != "laser" {
+		t.Errorf("Config() after round trip = %+v, want %+v", got, cfg)
+	}
+}
+
+func TestApplyConfig_RejectsEmptyWakePhrase(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	cfg := svc.Config()
+	cfg.WakePhrase = "  "
+	cfg.CommandPrefix = "/"
+	if err := svc.ApplyConfig(cfg); !errors.Is(err, ErrEmptyWakePhrase) {
+		t.Fatalf("ApplyConfig error = %v, want %v", err, ErrEmptyWakePhrase)
+	}
+	if got := handle(t, svc, stt, "u1", "laser stop"); got != "!stop" {
+		t.Errorf("after rejected config = %q, want settings unchanged", got)
+	}
+}
+
+func TestApplyConfig_Concurrent(t *testing.T) {
+	svc := NewVoiceService(staticSTT("laser stop"), "laser", nil, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+				if err != nil || (got != "!stop" && got != "/stop") {
+					t.Errorf("HandleVoice = %q, %v; want a stop command", got, err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		cfg := svc.Config()
+		cfg.CommandPrefix = []string{"!", "/"}[i%2]
+		if err := svc.ApplyConfig(cfg); err != nil {
+			t.Fatalf("ApplyConfig error: %v", err)
+		}
+	}
+	wg.Wait()
+}
+
+func TestApplyConfig_NotBlockedByLLM(t *testing.T) {
+	llm := &gatedLLM{reply: "Daft Punk", release: make(chan struct{}), started: make(chan struct{})}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Daft Punk"}, {Name: "Justice"}}}
+	svc := NewVoiceService(staticSTT("laser play the french robots"), "laser", llm, opts)
+
+	done := make(chan string)
+	go func() {
+		got, _ := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+		done <- got
+	}()
+	<-llm.started
+
+	applied := make(chan error)
+	go func() {
+		cfg := svc.Config()
+		cfg.CommandPrefix = "/"
+		applied <- svc.ApplyConfig(cfg)
+	}()
+	select {
+	case err := <-applied:
+		if err != nil {
+			t.Fatalf("ApplyConfig error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ApplyConfig blocked while the LLM was matching a play query")
+	}
+
+	close(llm.release)
+	// The command is built with the settings in place once the match is back.
+	if got := <-done; got != "/play Daft Punk" {
+		t.Errorf("HandleVoice = %q, want %q", got, "/play Daft Punk")
+	}
+}
+
+func TestApplyConfig_NotBlockedByParseMethods(t *testing.T) {
+	const query = "laser play the french robots"
+	methods := map[string]func(*VoiceService){
+		"Explain":         func(svc *VoiceService) { svc.Explain(context.Background(), query) },
+		"ParseBatch":      func(svc *VoiceService) { svc.ParseBatch(context.Background(), []string{query}) },
+		"ParseCommands":   func(svc *VoiceService) { svc.ParseCommands(context.Background(), query) },
+		"ParseCandidates": func(svc *VoiceService) { svc.ParseCandidates(context.Background(), query) },
+		"NoMatchReason":   func(svc *VoiceService) { svc.NoMatchReason(context.Background(), query) },
+	}
+	for name, parse := range methods {
+		t.Run(name, func(t *testing.T) {
+			llm := &gatedLLM{reply: "Daft Punk", release: make(chan struct{}), started: make(chan struct{})}
+			opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Daft Punk"}, {Name: "Justice"}}}
+			svc := NewVoiceService(staticSTT("laser stop"), "laser", llm, opts)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				parse(svc)
+			}()
+			<-llm.started
+			defer func() {
+				close(llm.release)
+				<-done
+			}()
+
+			handled := make(chan string)
+			go func() {
+				cfg := svc.Config()
+				cfg.CommandPrefix = "/"
+				if err := svc.ApplyConfig(cfg); err != nil {
+					t.Errorf("ApplyConfig error: %v", err)
+				}
+				got, _ := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+				handled <- got
+			}()
+			select {
+			case got := <-handled:
+				if got != "/stop" {
+					t.Errorf("HandleVoice = %q, want %q", got, "/stop")
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("ApplyConfig and HandleVoice blocked while %s waited on the LLM", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"strings"
+	"time"
+)
+
+// defaultConfirmTimeout is how long a pending confirmation stays valid.
+const defaultConfirmTimeout = 15 * time.Second
+
+// confirmCommand is the internal name of the "yes" / "confirm" follow-up.
+const confirmCommand = "confirm"
+
+// pendingConfirmation is a command waiting for the user to confirm it.
+type pendingConfirmation struct {
+	cmd     VoiceCommand
+	expires time.Time
+}
+
+// SetConfirmCommands sets which commands (by name, e.g. "clear") must be confirmed
+// with a follow-up "yes" or "confirm" before they are sent. None by default.
+func (s *VoiceService) SetConfirmCommands(names []string) {
+	confirm := make(map[string]bool, len(names))
+	for _, name := range names {
+		confirm[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	s.confirm = confirm
+}
+
+// SetConfirmTimeout sets how long a pending confirmation waits for the follow-up.
+func (s *VoiceService) SetConfirmTimeout(d time.Duration) {
+	s.confirmTimeout = d
+}
+
+// markConfirmation flags commands that must be confirmed before they're sent.
+// phrase is the wake phrase the follow-up must start with.
+func (s *VoiceService) markConfirmation(cmd *VoiceCommand, phrase string) {
+	if !s.confirm[cmd.Name] {
+		return
+	}
+	cmd.RequiresConfirmation = true
+	cmd.FollowUp = strings.TrimSpace(phrase + " " + confirmCommand)
+	if !s.requireWake {
+		cmd.FollowUp = confirmCommand
+	}
+}
+
+// resolveConfirmation applies the per-user confirmation state to a parsed command.
+// A command needing confirmation is held and nothing is sent; a later "confirm"
+// from the same user in the same channel releases it if it hasn't expired. Any
+// other command discards whatever was pending. Returns false when nothing
+// should be sent; a held command is still returned so callers can report it.
+func (s *VoiceService) resolveConfirmation(key stateKey, cmd VoiceCommand) (VoiceCommand, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, hasPending := s.pending.take(key)
+
+	switch {
+	case cmd.Name == confirmCommand:
+		if !hasPending || !s.clock.Now().Before(pending.expires) {
+			return VoiceCommand{}, false
+		}
+		return pending.cmd, true
+	case cmd.RequiresConfirmation:
+		s.pending.set(key, pendingConfirmation{cmd: cmd, expires: s.clock.Now().Add(s.confirmTimeout)})
+		return cmd, false
+	}
+	return cmd, true
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// handle runs a transcription through HandleVoice for the given user in "ch1".
+func handle(t *testing.T, svc *VoiceService, stt *mockSTT, userID, text string) string {
+	t.Helper()
+	stt.text = text
+	got, err := svc.HandleVoice(context.Background(), "ch1", userID, []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice(%q) error: %v", text, err)
+	}
+	return got
+}
+
+func newConfirmService(stt *mockSTT) (*VoiceService, *fakeClock) {
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetConfirmCommands([]string{"clear"})
+	svc.SetConfirmTimeout(10 * time.Second)
+
+	clock := newFakeClock()
+	svc.SetClock(clock)
+	return svc, clock
+}
+
+func TestConfirmation_ParseFlagsCommand(t *testing.T) {
+	svc, _ := newConfirmService(&mockSTT{})
+
+	cmd, ok := svc.parseCommand(context.Background(), "laser clear the queue")
+	if !ok {
+		t.Fatal("parseCommand returned no match")
+	}
+	if cmd.Text != "!clear" || !cmd.RequiresConfirmation {
+		t.Errorf("parseCommand = %+v, want !clear requiring confirmation", cmd)
+	}
+	if cmd.FollowUp != "laser confirm" {
+		t.Errorf("FollowUp = %q, want %q", cmd.FollowUp, "laser confirm")
+	}
+
+	if cmd, _ := svc.parseCommand(context.Background(), "laser stop"); cmd.RequiresConfirmation {
+		t.Errorf("stop should not require confirmation")
+	}
+}
+
+func TestConfirmation_Confirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		confirm string
+	}{
+		{"yes", "laser yes"},
+		{"confirm", "laser confirm"},
+		{"with filler", "hey laser yeah"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stt := &mockSTT{}
+			svc, _ := newConfirmService(stt)
+
+			if got := handle(t, svc, stt, "u1", "laser clear queue"); got != "" {
+				t.Fatalf("destructive command sent before confirmation: %q", got)
+			}
+			if got := handle(t, svc, stt, "u1", tt.confirm); got != "!clear" {
+				t.Errorf("after %q HandleVoice = %q, want %q", tt.confirm, got, "!clear")
+			}
+			if got := handle(t, svc, stt, "u1", tt.confirm); got != "" {
+				t.Errorf("second confirmation = %q, want nothing pending", got)
+			}
+		})
+	}
+}
+
+func TestConfirmation_TimeoutExpires(t *testing.T) {
+	stt := &mockSTT{}
+	svc, clock := newConfirmService(stt)
+
+	handle(t, svc, stt, "u1", "laser clear queue")
+	clock.Advance(11 * time.Second)
+
+	if got := handle(t, svc, stt, "u1", "laser confirm"); got != "" {
+		t.Errorf("confirmation after timeout = %q, want empty", got)
+	}
+}
+
+func TestConfirmation_PerUser(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newConfirmService(stt)
+
+	handle(t, svc, stt, "u1", "laser clear queue")
+	if got := handle(t, svc, stt, "u2", "laser yes"); got != "" {
+		t.Errorf("other user's confirmation = %q, want empty", got)
+	}
+	if got := handle(t, svc, stt, "u1", "laser yes"); got != "!clear" {
+		t.Errorf("own confirmation = %q, want %q", got, "!clear")
+	}
+}
+
+func TestConfirmation_OtherCommandDiscardsPending(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newConfirmService(stt)
+
+	handle(t, svc, stt, "u1", "laser clear queue")
+	if got := handle(t, svc, stt, "u1", "laser never mind"); got != "!cancel" {
+		t.Errorf("cancel = %q, want %q", got, "!cancel")
+	}
+	if got := handle(t, svc, stt, "u1", "laser confirm"); got != "" {
+		t.Errorf("confirmation after cancel = %q, want empty", got)
+	}
+}
+
+func TestConfirmation_NotConfiguredSendsImmediately(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	if got := handle(t, svc, stt, "u1", "laser clear queue"); got != "!clear" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!clear")
+	}
+	if got := handle(t, svc, stt, "u1", "laser yes"); got != "" {
+		t.Errorf("confirmation with nothing pending = %q, want empty", got)
+	}
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// ConfirmationData is the data available to a confirmation text template.
+type ConfirmationData struct {
+	// Name is the command name, e.g. "play".
+	Name string
+	// Args is the command text after the name without flags like "--shuffle",
+	// e.g. "never gonna give you up".
+	Args string
+}
+
+// defaultConfirmationTemplates are the confirmation texts set up by
+// NewVoiceService.
+var defaultConfirmationTemplates = map[string]string{
+	"stop": "Stopping playback",
+	"play": "Playing {{.Args}}",
+	"pr":   "Playing something random",
+	// Spoken after an admin renames the bot, since nothing is sent to chat.
+	renameCommand: "Call me {{.Args}} from now on",
+}
+
+// parseConfirmationTemplates parses the default confirmation templates.
+func parseConfirmationTemplates() map[string]*template.Template {
+	templates := make(map[string]*template.Template, len(defaultConfirmationTemplates))
+	for name, tmpl := range defaultConfirmationTemplates {
+		templates[name] = template.Must(template.New(name).Parse(tmpl))
+	}
+	return templates
+}
+
+// SetConfirmationTemplate sets the text HandleVoiceDetailed returns as
+// Confirmation for the named command, for a TTS layer to speak back. The
+// template is parsed with text/template and receives ConfirmationData, e.g.
+// "Setting the volume to {{.Args}}". An empty template removes it, so the
+// command has no confirmation. Defaults exist for stop, play and pr.
+func (s *VoiceService) SetConfirmationTemplate(name, tmpl string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if tmpl == "" {
+		delete(s.confirmTexts, name)
+		return nil
+	}
+	t, err := template.New(name).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("parse confirmation template for %q: %w", name, err)
+	}
+	s.confirmTexts[name] = t
+	return nil
+}
+
+// ConfirmationText renders the confirmation text for a command, or "" if its
+// command has no template.
+func (s *VoiceService) ConfirmationText(cmd VoiceCommand) string {
+	t, ok := s.confirmTexts[cmd.Name]
+	if !ok {
+		return ""
+	}
+	var text strings.Builder
+	if err := t.Execute(&text, ConfirmationData{Name: cmd.Name, Args: confirmationArgs(cmd.Text)}); err != nil {
+		log.Printf("failed to render confirmation text for %s: %v", cmd.Name, err)
+		return ""
+	}
+	return text.String()
+}
+
+// confirmationArgs returns the command text after the name with any flags
+// such as "--shuffle" removed, since they aren't meant to be spoken.
+func confirmationArgs(text string) string {
+	_, args, _ := strings.Cut(text, " ")
+	if !strings.Contains(args, "--") {
+		return args
+	}
+	words := slices.DeleteFunc(strings.Fields(args), func(w string) bool { return strings.HasPrefix(w, "--") })
+	return strings.Join(words, " ")
+}
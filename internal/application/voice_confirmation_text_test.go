@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestConfirmationText_Defaults(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser stop", "Stopping playback"},
+		{"laser play never gonna give you up", "Playing never gonna give you up"},
+		{"laser shuffle play never gonna give you up", "Playing never gonna give you up"},
+		{"laser play random", "Playing something random"},
+		{"laser skip", ""},
+	}
+	for _, tt := range tests {
+		stt.text = tt.input
+		result, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+		if err != nil {
+			t.Fatalf("HandleVoiceDetailed(%q) error: %v", tt.input, err)
+		}
+		if result.Confirmation != tt.want {
+			t.Errorf("Confirmation for %q = %q, want %q", tt.input, result.Confirmation, tt.want)
+		}
+	}
+
+	stt.text = "what a nice day"
+	result, _ := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if result.Confirmation != "" {
+		t.Errorf("Confirmation without a command = %q, want empty", result.Confirmation)
+	}
+}
+
+func TestConfirmationText_Custom(t *testing.T) {
+	svc := newTestService()
+	svc.SetCommandPrefix("/")
+	if err := svc.SetConfirmationTemplate("Volume", "Setting the volume to {{.Args}}"); err != nil {
+		t.Fatalf("SetConfirmationTemplate error: %v", err)
+	}
+	if err := svc.SetConfirmationTemplate("stop", ""); err != nil {
+		t.Fatalf("SetConfirmationTemplate error: %v", err)
+	}
+	if err := svc.SetConfirmationTemplate("skip", "{{.Missing"); err == nil {
+		t.Error("SetConfirmationTemplate with a bad template: want error")
+	}
+
+	tests := []struct {
+		cmd  VoiceCommand
+		want string
+	}{
+		{svc.command("volume", "50"), "Setting the volume to 50"},
+		{svc.command("stop"), ""},
+		{svc.command("skip"), ""},
+		{svc.command("play", "some jazz"), "Playing some jazz"},
+	}
+	for _, tt := range tests {
+		if got := svc.ConfirmationText(tt.cmd); got != tt.want {
+			t.Errorf("ConfirmationText(%q) = %q, want %q", tt.cmd.Text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package application
+
+import "context"
+
+// deferredMatchKey marks a context whose play queries are left for
+// matchDeferred to match, so the LLM call happens after configMu is released.
+type deferredMatchKey struct{}
+
+// withDeferredMatch returns a context marking play query matching as deferred.
+func withDeferredMatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deferredMatchKey{}, true)
+}
+
+// isDeferredMatch reports whether ctx defers play query matching.
+func isDeferredMatch(ctx context.Context) bool {
+	deferred, _ := ctx.Value(deferredMatchKey{}).(bool)
+	return deferred
+}
+
+// matchSettings are the runtime settings matchPlayQuery reads, copied while
+// configMu is held.
+type matchSettings struct {
+	local    bool
+	phonetic bool
+}
+
+// matchSettings returns the current match settings. The caller must hold configMu.
+func (s *VoiceService) matchSettings() matchSettings {
+	return matchSettings{local: s.localMatch, phonetic: s.phonetic}
+}
+
+// deferredMatch is a play query parsed but not yet matched. The trace's
+// Command plays the raw query until finishMatch replaces it.
+type deferredMatch struct {
+	shuffle  bool
+	settings matchSettings
+	matched  string // set by matchDeferred
+}
+
+// matchDeferred matches the trace's deferred play query, if any, against the
+// play options. It doesn't need configMu, which must not be held while it
+// waits on the options source and the LLM.
+func (s *VoiceService) matchDeferred(ctx context.Context, trace CommandTrace) CommandTrace {
+	if trace.match == nil {
+		return trace
+	}
+	m := *trace.match
+	m.matched, trace.Candidates, trace.Branch, trace.MatchError = s.matchPlayQuery(ctx, trace.Query, m.settings)
+	trace.match = &m
+	return trace
+}
+
+// finishMatch returns the trace's command, playing the match found by
+// matchDeferred in place of the raw query. The caller must hold configMu.
+func (s *VoiceService) finishMatch(trace CommandTrace) VoiceCommand {
+	cmd := trace.Command
+	if trace.match == nil || trace.match.matched == "" {
+		return cmd
+	}
+	played := s.playCommand("play", trace.match.matched, trace.match.shuffle)
+	played.WakeToken = cmd.WakeToken
+	played.FillerPrefix = cmd.FillerPrefix
+	played.RequiresConfirmation = cmd.RequiresConfirmation
+	played.FollowUp = cmd.FollowUp
+	return played
+}
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"context"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// dryRunKey marks a context whose parse must leave caches and limits as it
+// found them, as Explain promises.
+type dryRunKey struct{}
+
+// withDryRun returns a context marking the parse as a dry run.
+func withDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// isDryRun reports whether ctx belongs to a dry run.
+func isDryRun(ctx context.Context) bool {
+	dry, _ := ctx.Value(dryRunKey{}).(bool)
+	return dry
+}
+
+// indexFor returns an index for the options like optionIndexFor. A dry run
+// reuses the cached index but never replaces it or renews its fetch time.
+func (s *VoiceService) indexFor(ctx context.Context, options []bot.PlayOption) *optionIndex {
+	if !isDryRun(ctx) {
+		return s.optionIndexFor(options)
+	}
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	if s.index != nil && s.index.covers(options) {
+		return s.index
+	}
+	return newOptionIndex(options)
+}
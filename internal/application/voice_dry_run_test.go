@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// newDryRunService returns a service whose play queries reach the LLM, with
+// its only LLM slot taken and a hook counting reported LLM calls.
+func newDryRunService(t *testing.T) (*VoiceService, *countingLLM, *int) {
+	t.Helper()
+	llm := &countingLLM{reply: "Justice"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}, {Name: "Justice"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+	svc.SetLLMConcurrency(1, LLMLimitPassthrough)
+	if _, err := svc.acquireLLM(context.Background()); err != nil {
+		t.Fatalf("acquireLLM: %v", err)
+	}
+	reported := 0
+	svc.SetLLMUsageHook(func([]bot.LLMMessage, string) { reported++ })
+	return svc, llm, &reported
+}
+
+// assertUntouched fails if a dry run cached an option index, changed the LLM
+// slots or reported an LLM call.
+func assertUntouched(t *testing.T, svc *VoiceService, reported int) {
+	t.Helper()
+	if svc.index != nil {
+		t.Error("dry run cached an option index")
+	}
+	if got := len(svc.llmSlots); got != 1 {
+		t.Errorf("LLM slots in use = %d after dry run, want 1", got)
+	}
+	if reported != 0 {
+		t.Errorf("usage hook called %d times for a dry run, want 0", reported)
+	}
+}
+
+func TestExplain_DryRun(t *testing.T) {
+	svc, llm, reported := newDryRunService(t)
+
+	trace, ok := svc.Explain(context.Background(), "laser play that french duo")
+	if !ok || trace.Command.Text != "!play Justice" || trace.Branch != BranchLLM {
+		t.Fatalf("Explain = %q via %v (ok=%v), want the LLM's pick", trace.Command.Text, trace.Branch, ok)
+	}
+	if llm.calls != 1 {
+		t.Errorf("LLM calls = %d, want 1", llm.calls)
+	}
+	assertUntouched(t, svc, *reported)
+}
+
+func TestDryRun_ReusesCachedIndex(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: "Justice"}, &mockPlayOptions{options: []bot.PlayOption{{Name: "Justice"}}})
+	parse(t, svc, "laser play that french duo")
+	cached := svc.index
+
+	svc.Explain(context.Background(), "laser play that french duo")
+	if svc.index != cached {
+		t.Error("dry run replaced the cached option index")
+	}
+}
+
+func TestParseBatch_DryRun(t *testing.T) {
+	svc, llm, reported := newDryRunService(t)
+
+	for _, input := range []string{"laser play that french duo", "laser stop", "laser play the itsworking track"} {
+		results := svc.ParseBatch(context.Background(), []string{input})
+		if results[0].Command == "" {
+			t.Errorf("ParseBatch produced no command for %q", input)
+		}
+		assertUntouched(t, svc, *reported)
+	}
+	if llm.calls != 1 {
+		t.Errorf("LLM calls = %d, want 1", llm.calls)
+	}
+}
@@ -0,0 +1,53 @@
+package application
+
+// SetFirstWordWake makes the first word of a transcription wake the bot when
+// it is within n letters of the wake phrase or one of its alternates, as in
+// "lazor stop" at 1, regardless of SetWakeSensitivity. Poor STT often garbles a
+// wake word said first, and a word that opens the clip is rarely anything else.
+// The first word may also run into the command, as in "laserstop" or
+// "lazorskip". n is clamped to [0, MaxWakeSensitivity]; 0 (the default) turns
+// the check off. It has no effect when a wake regexp is set.
+func (s *VoiceService) SetFirstWordWake(n int) {
+	s.firstWordWake = clampWakeSensitivity(n)
+}
+
+// firstWordWakeText returns the command words after a wake phrase found by the
+// first-word check. lower holds the first fields, lowercased and trimmed.
+func (s *VoiceService) firstWordWakeText(phrase string, fields, lower []string) (commandWords, wakeMatch, bool) {
+	if s.firstWordWake == 0 || len(lower) == 0 {
+		return commandWords{}, wakeMatch{}, false
+	}
+	first := []rune(lower[0])
+	spellings := append([]string{phrase}, s.alternates[phrase]...)
+
+	for _, wake := range spellings {
+		if len(fields) > 1 && s.nearFirstWord([]rune(wake), first) {
+			return s.skipLeadIn(newCommandWords(fields[1:])), wakeMatch{token: lower[0]}, true
+		}
+	}
+	// The wake word and the command run together: try each split of the first
+	// word whose start is close to the wake phrase and whose rest starts a command.
+	for _, wake := range spellings {
+		w := []rune(wake)
+		for n := len(w) - s.firstWordWake; n <= len(w)+s.firstWordWake && n < len(first); n++ {
+			if n <= 0 || !s.nearFirstWord(w, first[:n]) {
+				continue
+			}
+			rest := append([]string{string(first[n:])}, fields[1:]...)
+			cw := s.skipLeadIn(newCommandWords(rest))
+			if s.startsCommand(cw.text()) {
+				return cw, wakeMatch{token: string(first[:n])}, true
+			}
+		}
+	}
+	return commandWords{}, wakeMatch{}, false
+}
+
+// nearFirstWord reports whether word is within the first-word threshold of
+// wake. Short wake phrases must match exactly, as with the wake sensitivity.
+func (s *VoiceService) nearFirstWord(wake, word []rune) bool {
+	if len(wake) < minFuzzyWordLength {
+		return string(wake) == string(word)
+	}
+	return levenshtein(word, wake) <= s.firstWordWake
+}
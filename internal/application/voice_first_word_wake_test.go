@@ -0,0 +1,58 @@
+package application
+
+import "testing"
+
+func TestFirstWordWake(t *testing.T) {
+	svc := newTestService()
+	svc.SetFirstWordWake(1)
+	svc.SetWakeAlternates("laser", []string{"lazer"})
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"run together", "laserstop", "!stop"},
+		{"run together with casing", "Laserskip.", "!skip"},
+		{"run together query", "laserplay Daft Punk", "!play Daft Punk"},
+		{"garbled run together", "lazorstop", "!stop"},
+		{"alternate run together", "lazerpause", "!pause"},
+		{"garbled first word", "lasor stop", "!stop"},
+		{"garbled alternate", "lazar stop", "!stop"},
+		{"garbled first word with punctuation", "Lasor, skip.", "!skip"},
+		{"too garbled", "lepsor stop", ""},
+		{"run together without a command", "laserbeams are cool", ""},
+		{"only the first word is loosened", "hey lasor stop", ""},
+		{"bare garbled word", "lasor", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstWordWake_OffByDefault(t *testing.T) {
+	svc := newTestService()
+
+	for _, input := range []string{"laserstop", "lasor stop"} {
+		if got := parse(t, svc, input); got != "" {
+			t.Errorf("parse(%q) = %q, want no command", input, got)
+		}
+	}
+}
+
+func TestFirstWordWake_IndependentOfSensitivity(t *testing.T) {
+	svc := newTestService()
+	svc.SetFirstWordWake(2)
+	svc.SetWakeSensitivity(0)
+
+	if got := parse(t, svc, "lazor stop"); got != "!stop" {
+		t.Errorf("first word = %q, want %q", got, "!stop")
+	}
+	if got := parse(t, svc, "hey lazor stop"); got != "" {
+		t.Errorf("second word = %q, want no command at sensitivity 0", got)
+	}
+}
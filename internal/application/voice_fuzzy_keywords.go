@@ -0,0 +1,61 @@
+package application
+
+// minFuzzyWordLength is the shortest keyword word that may be matched with a
+// typo. Shorter words like "yes" or "np" have too many one-letter neighbours.
+const minFuzzyWordLength = 4
+
+// SetFuzzyKeywords enables matching command keywords one edit away from what
+// STT transcribed, e.g. "stob" or "stopp" for "stop" and "skib" for "skip".
+// It is only tried when nothing matched exactly, and a word close to more than
+// one keyword matches none of them.
+func (s *VoiceService) SetFuzzyKeywords(enabled bool) {
+	s.fuzzyKeywords = enabled
+}
+
+// fuzzyKeyword returns the keyword command whose phrase the words start with,
+// allowing a single edit in a word of at least minFuzzyWordLength letters. The
+// longest such phrase wins, as with "stob that" (cancel) over "stob" (stop).
+// Returns false if no command is that close, or several are equally close.
+func fuzzyKeyword(words []string) (keywordCommand, bool) {
+	var found keywordCommand
+	bestLen, ambiguous := 0, false
+	for _, kc := range keywordCommands {
+		for _, phrase := range kc.phrases {
+			n := len(nameWords(phrase))
+			if n < bestLen || !nearPhrasePrefix(words, phrase) {
+				continue
+			}
+			if n == bestLen && found.name != kc.name {
+				ambiguous = true
+				continue
+			}
+			if n > bestLen {
+				ambiguous = false
+			}
+			found, bestLen = kc, n
+		}
+	}
+	return found, bestLen > 0 && !ambiguous
+}
+
+// nearPhrasePrefix reports whether words start with phrase, allowing one edit.
+func nearPhrasePrefix(words []string, phrase string) bool {
+	phraseWords := nameWords(phrase)
+	if len(words) < len(phraseWords) {
+		return false
+	}
+	edits := 0
+	for i, pw := range phraseWords {
+		if words[i] == pw {
+			continue
+		}
+		if len(pw) < minFuzzyWordLength {
+			return false
+		}
+		edits += levenshtein([]rune(words[i]), []rune(pw))
+		if edits > 1 {
+			return false
+		}
+	}
+	return true
+}
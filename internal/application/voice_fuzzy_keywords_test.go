@@ -0,0 +1,46 @@
+package application
+
+import "testing"
+
+func TestFuzzyKeywords(t *testing.T) {
+	svc := newTestService()
+	svc.SetFuzzyKeywords(true)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"extra letter", "laser stopp", "!stop"},
+		{"substituted letter", "laser stob", "!stop"},
+		{"skib", "laser skib", "!skip"},
+		{"pause", "laser pawse", "!pause"},
+		{"multi-word phrase", "laser next sonh", "!skip"},
+		{"longest phrase wins", "laser stob that", "!cancel"},
+		{"exact still wins", "laser stop", "!stop"},
+		{"ambiguous", "laser stip", ""},
+		{"two edits", "laser stwbb", ""},
+		{"short word", "laser yas", ""},
+		{"play query untouched", "laser play skib", "!play skib"},
+		{"play query starting like a command", "laser play stopp the music", "!play stopp the music"},
+		{"search query untouched", "laser search stob", "!search stob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzyKeywords_OffByDefault(t *testing.T) {
+	svc := newTestService()
+
+	for _, input := range []string{"laser stopp", "laser stob", "laser skib"} {
+		if got := parse(t, svc, input); got != "" {
+			t.Errorf("parse(%q) = %q, want no match with fuzzy keywords off", input, got)
+		}
+	}
+}
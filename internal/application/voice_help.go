@@ -0,0 +1,45 @@
+package application
+
+import (
+	"slices"
+	"strings"
+)
+
+// helpCommand asks the bot to list what it understands, e.g. "laser what can
+// you do".
+const helpCommand = "help"
+
+// argumentCommands are the built-in commands that take arguments, and so have
+// no entry in keywordCommands. "queue" is what "queue this" is sent as.
+var argumentCommands = []string{"play", "playlist", "pr", "queue", "search", "volume", seekCommand, wakeSensitivityCommand}
+
+// internalCommands are the keyword commands the service resolves itself, as
+// "again" is replaced by the command it repeats, so none is ever sent by its
+// own name.
+var internalCommands = map[string]bool{
+	confirmCommand:   true,
+	queueThisCommand: true,
+	repeatCommand:    true,
+}
+
+// SupportedCommands returns the names of the built-in commands that can be
+// sent, sorted, leaving out the ones disabled with SetCommandEnabled. It is
+// meant for a handler that formats the reply to "!help". Commands the service
+// handles itself, such as "again", "confirm" and renaming the bot, aren't
+// listed.
+func (s *VoiceService) SupportedCommands() []string {
+	names := slices.Clone(argumentCommands)
+	for _, kc := range keywordCommands {
+		if !internalCommands[kc.name] {
+			names = append(names, kc.name)
+		}
+	}
+	names = slices.DeleteFunc(names, s.commandDisabled)
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// helpArgs lists the supported commands in a help command's Args.
+func (s *VoiceService) helpArgs() map[string]string {
+	return map[string]string{"commands": strings.Join(s.SupportedCommands(), ",")}
+}
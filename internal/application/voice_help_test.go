@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHelp(t *testing.T) {
+	svc := newTestService()
+
+	inputs := []string{
+		"laser help",
+		"laser list commands",
+		"laser list your commands",
+		"laser what can you do",
+		"Laser, what can you do?",
+		"hey laser what can i say",
+	}
+	for _, input := range inputs {
+		if got := parse(t, svc, input); got != "!help" {
+			t.Errorf("parse(%q) = %q, want %q", input, got, "!help")
+		}
+	}
+}
+
+func TestHelp_ListsSupportedCommands(t *testing.T) {
+	svc := newTestService()
+	svc.SetCommandEnabled("pr", false)
+
+	commands := svc.SupportedCommands()
+	for _, name := range []string{"play", "stop", "volume", "seek", "queue", "help"} {
+		if !slices.Contains(commands, name) {
+			t.Errorf("SupportedCommands() = %v, missing %q", commands, name)
+		}
+	}
+	if slices.Contains(commands, "pr") {
+		t.Errorf("SupportedCommands() = %v, want disabled pr left out", commands)
+	}
+	for _, name := range []string{"queue-this", "again", "confirm", "rename"} {
+		if slices.Contains(commands, name) {
+			t.Errorf("SupportedCommands() = %v, want internal %q left out", commands, name)
+		}
+	}
+	if !slices.IsSorted(commands) {
+		t.Errorf("SupportedCommands() = %v, want sorted", commands)
+	}
+
+	trace, ok := svc.Explain(context.Background(), "laser what can you do")
+	if !ok || trace.Command.Args["commands"] != strings.Join(commands, ",") {
+		t.Errorf("help Args = %v, want the supported commands", trace.Command.Args)
+	}
+}
+
+func TestHelp_ListsEverySentCommand(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetLastResults("ch1", "u1", []string{"itsworking"})
+	supported := svc.SupportedCommands()
+
+	inputs := []string{
+		"laser play daft punk",
+		"laser play my chill playlist",
+		"laser play random",
+		"laser play the first result",
+		"laser search justice",
+		"laser queue this",
+		"laser volume 50",
+		"laser turn it up",
+		"laser skip to 1:30",
+		"laser be more sensitive",
+		"laser stop",
+		"laser again",
+	}
+	for _, kc := range keywordCommands {
+		if !internalCommands[kc.name] {
+			inputs = append(inputs, "laser "+kc.phrases[0])
+		}
+	}
+	for _, input := range inputs {
+		got := handle(t, svc, stt, "u1", input)
+		if got == "" {
+			t.Errorf("handle(%q) sent nothing", input)
+			continue
+		}
+		name := strings.Fields(strings.TrimPrefix(got, "!"))[0]
+		if !slices.Contains(supported, name) {
+			t.Errorf("handle(%q) = %q, but SupportedCommands() = %v is missing %q", input, got, supported, name)
+		}
+	}
+}
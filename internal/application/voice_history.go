@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// defaultRecentCommands is how many commands RecentCommands keeps per channel.
+const defaultRecentCommands = 20
+
+// RecentCommand is a voice command that HandleVoice sent to chat.
+type RecentCommand = bot.CommandRecord
+
+// commandRing holds the most recent commands for a channel, overwriting the
+// oldest once full.
+type commandRing struct {
+	entries []RecentCommand
+	next    int // index the next entry is written to once full
+}
+
+// add appends a command, dropping the oldest if the ring holds size entries.
+func (r *commandRing) add(cmd RecentCommand, size int) {
+	if len(r.entries) < size {
+		r.entries = append(r.entries, cmd)
+		return
+	}
+	r.entries[r.next] = cmd
+	r.next = (r.next + 1) % len(r.entries)
+}
+
+// list returns the commands oldest first.
+func (r *commandRing) list() []RecentCommand {
+	out := make([]RecentCommand, 0, len(r.entries))
+	out = append(out, r.entries[r.next:]...)
+	return append(out, r.entries[:r.next]...)
+}
+
+// SetHistoryStore persists the recent command history in store, replacing the
+// in-memory history with what the store has saved, so "again" keeps working
+// across restarts. Call it right after constructing the service. Each handled
+// command then saves its channel's history; save failures are logged and the
+// in-memory history carries on. A nil store keeps history in memory only.
+func (s *VoiceService) SetHistoryStore(ctx context.Context, store bot.HistoryStore) error {
+	var saved map[string][]bot.CommandRecord
+	if store != nil {
+		var err error
+		if saved, err = store.Load(ctx); err != nil {
+			return fmt.Errorf("load command history: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.historyStore = store
+	s.history = make(map[string]*commandRing)
+	if s.historySize <= 0 {
+		return nil
+	}
+	for channelID, commands := range saved {
+		ring := &commandRing{}
+		for _, cmd := range commands {
+			ring.add(cmd, s.historySize)
+		}
+		s.history[channelID] = ring
+	}
+	return nil
+}
+
+// saveHistory writes the channel's history to the history store, if one is set.
+// saveMu keeps concurrent saves for a channel in the order they were made.
+func (s *VoiceService) saveHistory(ctx context.Context, channelID string) {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	s.mu.Lock()
+	store := s.historyStore
+	var commands []RecentCommand
+	if ring, ok := s.history[channelID]; ok {
+		commands = ring.list()
+	}
+	s.mu.Unlock()
+
+	if store == nil {
+		return
+	}
+	if err := store.Save(ctx, channelID, commands); err != nil {
+		log.Printf("save command history for channel %s: %v", channelID, err)
+	}
+}
+
+// SetRecentCommandsSize sets how many commands RecentCommands keeps per channel
+// (default 20). Zero or less disables the history. Existing history is cleared.
+func (s *VoiceService) SetRecentCommandsSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.historySize = n
+	s.history = make(map[string]*commandRing)
+}
+
+// RecentCommands returns the commands HandleVoice sent for the channel, oldest
+// first. Transcriptions that matched nothing, or are awaiting confirmation,
+// are not included.
+func (s *VoiceService) RecentCommands(channelID string) []RecentCommand {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ring, ok := s.history[channelID]
+	if !ok {
+		return nil
+	}
+	return ring.list()
+}
+
+// recordCommand adds a sent command to the channel's history, reporting false
+// if the history is disabled.
+func (s *VoiceService) recordCommand(channelID, userID string, cmd VoiceCommand) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.historySize <= 0 {
+		return false
+	}
+	ring, ok := s.history[channelID]
+	if !ok {
+		ring = &commandRing{}
+		s.history[channelID] = ring
+	}
+	ring.add(RecentCommand{Time: s.clock.Now(), UserID: userID, Name: cmd.Name, Text: cmd.Text}, s.historySize)
+	return true
+}
+
+// lastCommand returns the most recent command the user sent in the channel.
+func (s *VoiceService) lastCommand(channelID, userID string) (RecentCommand, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ring, ok := s.history[channelID]
+	if !ok {
+		return RecentCommand{}, false
+	}
+	entries := ring.list()
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].UserID == userID {
+			return entries[i], true
+		}
+	}
+	return RecentCommand{}, false
+}
@@ -0,0 +1,222 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestRecentCommands(t *testing.T) {
+	stt := &mockSTT{}
+	clock := newFakeClock()
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetClock(clock)
+	start := clock.Now()
+
+	handleIn(t, svc, stt, "ch1", "u1", "laser stop")
+	clock.Advance(time.Second)
+	handleIn(t, svc, stt, "ch1", "u2", "hello there")
+	handleIn(t, svc, stt, "ch2", "u1", "laser skip")
+	clock.Advance(time.Second)
+	handleIn(t, svc, stt, "ch1", "u2", "laser play random")
+
+	want := []RecentCommand{
+		{Time: start, UserID: "u1", Text: "!stop"},
+		{Time: start.Add(2 * time.Second), UserID: "u2", Text: "!pr"},
+	}
+	got := svc.RecentCommands("ch1")
+	if len(got) != len(want) {
+		t.Fatalf("RecentCommands(ch1) = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if !got[i].Time.Equal(want[i].Time) || got[i].UserID != want[i].UserID || got[i].Text != want[i].Text {
+			t.Errorf("RecentCommands(ch1)[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got := svc.RecentCommands("ch2"); len(got) != 1 || got[0].Text != "!skip" {
+		t.Errorf("RecentCommands(ch2) = %+v, want just !skip", got)
+	}
+	if got := svc.RecentCommands("ch3"); len(got) != 0 {
+		t.Errorf("RecentCommands(ch3) = %+v, want empty", got)
+	}
+}
+
+func TestRecentCommands_SizeBound(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetRecentCommandsSize(3)
+
+	for i := range 5 {
+		handleIn(t, svc, stt, "ch1", "u1", fmt.Sprintf("laser volume %d", i))
+	}
+
+	got := svc.RecentCommands("ch1")
+	want := []string{"!volume 2", "!volume 3", "!volume 4"}
+	if len(got) != len(want) {
+		t.Fatalf("RecentCommands = %+v, want %v", got, want)
+	}
+	for i, text := range want {
+		if got[i].Text != text {
+			t.Errorf("RecentCommands[%d] = %q, want %q", i, got[i].Text, text)
+		}
+	}
+}
+
+func TestRecentCommands_SkipsHeldCommands(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newConfirmService(stt)
+
+	handle(t, svc, stt, "u1", "laser clear the queue")
+	if got := svc.RecentCommands("ch1"); len(got) != 0 {
+		t.Fatalf("RecentCommands = %+v, want nothing before confirmation", got)
+	}
+	handle(t, svc, stt, "u1", "laser confirm")
+	if got := svc.RecentCommands("ch1"); len(got) != 1 || got[0].Text != "!clear" {
+		t.Errorf("RecentCommands = %+v, want just !clear", got)
+	}
+}
+
+func TestRecentCommands_Disabled(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetRecentCommandsSize(0)
+
+	handleIn(t, svc, stt, "ch1", "u1", "laser stop")
+	if got := svc.RecentCommands("ch1"); len(got) != 0 {
+		t.Errorf("RecentCommands = %+v, want empty when disabled", got)
+	}
+}
+
+func TestResetChannel_ClearsHistory(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	handleIn(t, svc, stt, "ch1", "u1", "laser stop")
+	svc.ResetChannel("ch1")
+	if got := svc.RecentCommands("ch1"); len(got) != 0 {
+		t.Errorf("RecentCommands after ResetChannel = %+v, want empty", got)
+	}
+}
+
+// memoryHistoryStore is a HistoryStore that keeps saved history in a map, so
+// it can outlive the VoiceService that wrote it.
+type memoryHistoryStore struct {
+	mu      sync.Mutex
+	saved   map[string][]bot.CommandRecord
+	saves   int
+	loadErr error
+	saveErr error
+}
+
+func (m *memoryHistoryStore) Load(_ context.Context) (map[string][]bot.CommandRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.loadErr != nil {
+		return nil, m.loadErr
+	}
+	out := make(map[string][]bot.CommandRecord, len(m.saved))
+	for ch, cmds := range m.saved {
+		out[ch] = append([]bot.CommandRecord(nil), cmds...)
+	}
+	return out, nil
+}
+
+func (m *memoryHistoryStore) Save(_ context.Context, channelID string, commands []bot.CommandRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saves++
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	if m.saved == nil {
+		m.saved = make(map[string][]bot.CommandRecord)
+	}
+	if len(commands) == 0 {
+		delete(m.saved, channelID)
+		return nil
+	}
+	m.saved[channelID] = append([]bot.CommandRecord(nil), commands...)
+	return nil
+}
+
+func newStoredHistoryService(t *testing.T, stt *mockSTT, store bot.HistoryStore) *VoiceService {
+	t.Helper()
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	if err := svc.SetHistoryStore(context.Background(), store); err != nil {
+		t.Fatalf("SetHistoryStore error: %v", err)
+	}
+	return svc
+}
+
+func TestHistoryStore_SurvivesRestart(t *testing.T) {
+	store := &memoryHistoryStore{}
+	stt := &mockSTT{}
+
+	svc := newStoredHistoryService(t, stt, store)
+	handleIn(t, svc, stt, "ch1", "u1", "laser play Daft Punk")
+	handleIn(t, svc, stt, "ch2", "u2", "laser skip")
+	if store.saves != 2 {
+		t.Errorf("saves = %d, want one per command", store.saves)
+	}
+
+	// A new service stands in for the bot after a restart.
+	restarted := newStoredHistoryService(t, stt, store)
+	if got := restarted.RecentCommands("ch1"); len(got) != 1 || got[0].Text != "!play Daft Punk" {
+		t.Errorf("RecentCommands(ch1) after restart = %+v, want the play command", got)
+	}
+	if got := handleIn(t, restarted, stt, "ch1", "u1", "laser again"); got != "!play Daft Punk" {
+		t.Errorf("again after restart = %q, want %q", got, "!play Daft Punk")
+	}
+}
+
+func TestHistoryStore_LoadTrimmedToSize(t *testing.T) {
+	store := &memoryHistoryStore{saved: map[string][]bot.CommandRecord{"ch1": {
+		{UserID: "u1", Name: "stop", Text: "!stop"},
+		{UserID: "u1", Name: "skip", Text: "!skip"},
+		{UserID: "u1", Name: "pause", Text: "!pause"},
+	}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, nil)
+	svc.SetRecentCommandsSize(2)
+	if err := svc.SetHistoryStore(context.Background(), store); err != nil {
+		t.Fatalf("SetHistoryStore error: %v", err)
+	}
+
+	got := svc.RecentCommands("ch1")
+	if len(got) != 2 || got[0].Text != "!skip" || got[1].Text != "!pause" {
+		t.Errorf("RecentCommands = %+v, want the newest two", got)
+	}
+}
+
+func TestHistoryStore_ResetChannel(t *testing.T) {
+	store := &memoryHistoryStore{}
+	stt := &mockSTT{}
+	svc := newStoredHistoryService(t, stt, store)
+
+	handleIn(t, svc, stt, "ch1", "u1", "laser stop")
+	svc.ResetChannel("ch1")
+	if _, ok := store.saved["ch1"]; ok {
+		t.Errorf("saved history for ch1 after ResetChannel = %+v, want none", store.saved["ch1"])
+	}
+}
+
+func TestHistoryStore_Errors(t *testing.T) {
+	loadErr := errors.New("store unavailable")
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, nil)
+	if err := svc.SetHistoryStore(context.Background(), &memoryHistoryStore{loadErr: loadErr}); !errors.Is(err, loadErr) {
+		t.Errorf("SetHistoryStore error = %v, want %v", err, loadErr)
+	}
+
+	// A failing save doesn't lose the in-memory history.
+	stt := &mockSTT{}
+	svc = newStoredHistoryService(t, stt, &memoryHistoryStore{saveErr: errors.New("disk full")})
+	handleIn(t, svc, stt, "ch1", "u1", "laser stop")
+	if got := handleIn(t, svc, stt, "ch1", "u1", "laser again"); got != "!stop" {
+		t.Errorf("again after a failed save = %q, want %q", got, "!stop")
+	}
+}
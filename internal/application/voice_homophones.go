@@ -0,0 +1,53 @@
+package application
+
+import "strings"
+
+// defaultHomophones map words STT commonly hears in place of a command keyword
+// to that keyword.
+var defaultHomophones = map[string]string{
+	"paws":  "pause",
+	"pours": "pause",
+	"plate": "play",
+	"pray":  "play",
+	"moot":  "mute",
+	"leaf":  "leave",
+	"cue":   "queue",
+}
+
+// SetHomophones replaces the words read as a command keyword when they start
+// a command, such as "paws" for "pause", so "laser paws" pauses. Only the first
+// word of a command is replaced, so queries like "play paws and claws" keep it.
+// Words are matched case-insensitively. Passing nil turns the replacement off.
+func (s *VoiceService) SetHomophones(homophones map[string]string) {
+	s.homophones = make(map[string]string, len(homophones))
+	s.AddHomophones(homophones)
+}
+
+// AddHomophones adds to the words read as a command keyword, keeping the
+// defaults. A word already mapped is replaced.
+func (s *VoiceService) AddHomophones(homophones map[string]string) {
+	for word, keyword := range homophones {
+		word = strings.ToLower(strings.TrimSpace(word))
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if word != "" && keyword != "" {
+			s.homophones[word] = keyword
+		}
+	}
+}
+
+// replaceHomophone returns the command words with a leading homophone replaced
+// by its keyword, and the word it replaced, or "" if none was. The words are
+// copied, not changed in place.
+func (s *VoiceService) replaceHomophone(cw commandWords) (commandWords, string) {
+	if len(cw.words) == 0 {
+		return cw, ""
+	}
+	keyword, ok := s.homophones[cw.words[0]]
+	if !ok {
+		return cw, ""
+	}
+	return commandWords{
+		words:  append([]string{keyword}, cw.words[1:]...),
+		spoken: append([]string{keyword}, cw.spoken[1:]...),
+	}, cw.words[0]
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHomophones(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"paws", "laser paws", "!pause"},
+		{"pours", "Laser, pours.", "!pause"},
+		{"plate", "laser plate never gonna give you up", "!play never gonna give you up"},
+		{"moot", "laser moot", "!mute"},
+		{"leaf", "laser leaf", "!leave"},
+		{"only the first word", "laser play paws and claws", "!play paws and claws"},
+		{"unrelated word", "laser plates", ""},
+		{"unrelated first word", "laser claws", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHomophones_Configurable(t *testing.T) {
+	svc := newTestService()
+	svc.AddHomophones(map[string]string{"Skiff": "skip"})
+
+	if got := parse(t, svc, "laser skiff"); got != "!skip" {
+		t.Errorf("added homophone = %q, want %q", got, "!skip")
+	}
+	if got := parse(t, svc, "laser paws"); got != "!pause" {
+		t.Errorf("default after AddHomophones = %q, want %q", got, "!pause")
+	}
+
+	svc.SetHomophones(nil)
+	for _, input := range []string{"laser paws", "laser skiff"} {
+		if got := parse(t, svc, input); got != "" {
+			t.Errorf("parse(%q) with homophones off = %q, want no command", input, got)
+		}
+	}
+}
+
+func TestHomophones_Traced(t *testing.T) {
+	svc := newTestService()
+
+	trace, ok := svc.Explain(context.Background(), "laser paws")
+	if !ok || trace.Homophone != "paws" || trace.Remainder != "pause" {
+		t.Errorf("Explain = %+v, want the replaced homophone traced", trace)
+	}
+	if trace, _ := svc.Explain(context.Background(), "laser pause"); trace.Homophone != "" {
+		t.Errorf("Homophone = %q for a keyword, want none", trace.Homophone)
+	}
+}
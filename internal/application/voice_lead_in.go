@@ -0,0 +1,73 @@
+package application
+
+import "strings"
+
+// defaultCommandFillers are words that may appear between the wake phrase and
+// the command in either adjacency mode, e.g. "laser um stop".
+var defaultCommandFillers = []string{"um", "uh", "er", "erm", "hmm", "please", "ok", "okay", "so", "hey"}
+
+// defaultMaxInterveningWords is how many other words may precede the command
+// in non-strict mode. It is zero so ordinary speech mentioning the wake word,
+// like "the laser show will stop soon", isn't read as a command.
+const defaultMaxInterveningWords = 0
+
+// SetStrictWakeAdjacency controls which words may sit between the wake phrase
+// and the command. In strict mode only command fillers (see SetCommandFillers)
+// may, so "laser could you please stop" produces nothing. Otherwise up to
+// SetMaxInterveningWords other words are skipped as well.
+func (s *VoiceService) SetStrictWakeAdjacency(strict bool) {
+	s.strictAdjacency = strict
+}
+
+// SetCommandFillers replaces the words that may appear between the wake phrase
+// and the command in either mode.
+func (s *VoiceService) SetCommandFillers(fillers []string) {
+	s.commandFillers = wordSet(fillers)
+}
+
+// wordSet lowercases the words into a set, skipping blanks.
+func wordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// SetMaxInterveningWords sets how many words other than fillers may precede the
+// command in non-strict mode (default 0), e.g. 2 for "laser could you please
+// stop". Zero allows fillers only.
+func (s *VoiceService) SetMaxInterveningWords(n int) {
+	s.maxIntervening = n
+}
+
+// skipLeadIn drops fillers and, in non-strict mode, a bounded number of other
+// words before the command. A negation right before the command is kept so the
+// command can be refused. If no command start is found within them, the words
+// are returned unchanged.
+func (s *VoiceService) skipLeadIn(cw commandWords) commandWords {
+	allowed := s.maxIntervening
+	if s.strictAdjacency {
+		allowed = 0
+	}
+
+	text, offsets := cw.text(), wordOffsets(cw.words)
+	skipped := 0
+	for i, word := range cw.words {
+		if s.startsCommand(text[offsets[i]:]) {
+			return cw.slice(i-negationBefore(cw.words, i), len(cw.words))
+		}
+		if rest := cw.slice(i, len(cw.words)); s.negatedCommand(rest) {
+			return rest
+		}
+		if !s.commandFillers[word] {
+			if skipped == allowed {
+				break
+			}
+			skipped++
+		}
+	}
+	return cw
+}
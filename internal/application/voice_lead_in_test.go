@@ -0,0 +1,104 @@
+package application
+
+import "testing"
+
+func TestWakeAdjacency_NonStrict(t *testing.T) {
+	svc := newTestService()
+	svc.SetMaxInterveningWords(2)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"adjacent", "laser stop", "!stop"},
+		{"filler", "laser um stop", "!stop"},
+		{"could you please", "laser could you please stop", "!stop"},
+		{"could you play", "laser could you play Daft Punk", "!play Daft Punk"},
+		{"fillers don't count", "laser um could you uh please skip", "!skip"},
+		{"too many words", "laser i really want you to stop", ""},
+		{"no command", "laser could you please", ""},
+		{"fillers only", "laser um", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWakeAdjacency_DefaultSkipsFillersOnly(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"adjacent", "laser stop", "!stop"},
+		{"filler", "laser um please stop", "!stop"},
+		{"laser show", "the laser show will stop soon", ""},
+		{"my laser", "my laser is gonna play something", ""},
+		{"laser pointer", "laser pointer stop", ""},
+		{"could you please", "laser could you please stop", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWakeAdjacency_Strict(t *testing.T) {
+	svc := newTestService()
+	svc.SetStrictWakeAdjacency(true)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"adjacent", "laser stop", "!stop"},
+		{"filler", "laser um stop", "!stop"},
+		{"several fillers", "hey laser uh please pause", "!pause"},
+		{"could you please", "laser could you please stop", ""},
+		{"could you play", "laser could you play Daft Punk", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWakeAdjacency_Configured(t *testing.T) {
+	svc := newTestService()
+	svc.SetStrictWakeAdjacency(true)
+	svc.SetCommandFillers([]string{" Could ", "you", "please"})
+
+	if got := parse(t, svc, "laser could you please stop"); got != "!stop" {
+		t.Errorf("parse with configured fillers = %q, want %q", got, "!stop")
+	}
+	if got := parse(t, svc, "laser hmm stop"); got != "" {
+		t.Errorf("parse with replaced fillers = %q, want no match", got)
+	}
+
+	svc.SetStrictWakeAdjacency(false)
+	svc.SetCommandFillers(nil)
+	svc.SetMaxInterveningWords(4)
+	if got := parse(t, svc, "laser i really want you to stop"); got != "" {
+		t.Errorf("parse = %q, want no match with five intervening words", got)
+	}
+	if got := parse(t, svc, "laser i really want to stop"); got != "!stop" {
+		t.Errorf("parse = %q, want %q with four intervening words", got, "!stop")
+	}
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"errors"
+)
+
+// LLMLimitPolicy says what a play query does when SetLLMConcurrency's limit
+// is reached.
+type LLMLimitPolicy int
+
+const (
+	// LLMLimitWait waits for another LLM call to finish, giving up when the
+	// context is done.
+	LLMLimitWait LLMLimitPolicy = iota
+	// LLMLimitPassthrough skips the LLM, handling the query as if the call had
+	// failed: it is matched locally if local matching is on, or sent as spoken.
+	LLMLimitPassthrough
+)
+
+// ErrLLMBusy is the match error of a play query that skipped the LLM because
+// the concurrency limit was reached under LLMLimitPassthrough.
+var ErrLLMBusy = errors.New("too many concurrent LLM calls")
+
+// SetLLMConcurrency limits how many LLM calls run at once across all users and
+// channels, for an LLM account with a global rate cap. policy decides whether
+// a play query arriving at the limit waits or skips the LLM. A max of zero or
+// less (the default) removes the limit. It must not be called while voice
+// clips are being handled.
+func (s *VoiceService) SetLLMConcurrency(max int, policy LLMLimitPolicy) {
+	s.llmPolicy = policy
+	if max <= 0 {
+		s.llmSlots = nil
+		return
+	}
+	s.llmSlots = make(chan struct{}, max)
+}
+
+// acquireLLM takes an LLM call slot, returning the function that gives it back.
+func (s *VoiceService) acquireLLM(ctx context.Context) (func(), error) {
+	slots := s.llmSlots
+	if slots == nil {
+		return func() {}, nil
+	}
+	release := func() { <-slots }
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if s.llmPolicy == LLMLimitPassthrough {
+		return nil, ErrLLMBusy
+	}
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
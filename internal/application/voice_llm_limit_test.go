@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// gatedLLM tracks how many calls run at once. Calls wait for release to be
+// closed, or return at once if it is nil.
+type gatedLLM struct {
+	reply   string
+	release chan struct{}
+	started chan struct{}
+
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (b *gatedLLM) ChatCompletion(_ context.Context, _ []bot.LLMMessage) (string, error) {
+	b.mu.Lock()
+	b.inFlight++
+	b.peak = max(b.peak, b.inFlight)
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.inFlight--
+		b.mu.Unlock()
+	}()
+
+	if b.started != nil {
+		b.started <- struct{}{}
+	}
+	if b.release != nil {
+		<-b.release
+	} else {
+		time.Sleep(time.Millisecond)
+	}
+	return b.reply, nil
+}
+
+func newLimitedService(llm bot.LLMService, max int, policy LLMLimitPolicy) *VoiceService {
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Daft Punk"}, {Name: "Justice"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+	svc.SetLLMConcurrency(max, policy)
+	return svc
+}
+
+func TestLLMConcurrency_CapRespected(t *testing.T) {
+	llm := &gatedLLM{reply: "Daft Punk"}
+	svc := newLimitedService(llm, 2, LLMLimitWait)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			trace, ok := svc.explain(context.Background(), "", "laser play the french robots")
+			if !ok || trace.Command.Text != "!play Daft Punk" || trace.Branch != BranchLLM {
+				t.Errorf("explain = (%q, %s), want an LLM match after waiting", trace.Command.Text, trace.Branch)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak := llm.peak; peak > 2 || peak == 0 {
+		t.Errorf("peak concurrent LLM calls = %d, want 1 or 2", peak)
+	}
+}
+
+func TestLLMConcurrency_Passthrough(t *testing.T) {
+	llm := &gatedLLM{reply: "Daft Punk", release: make(chan struct{}), started: make(chan struct{})}
+	svc := newLimitedService(llm, 1, LLMLimitPassthrough)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.explain(context.Background(), "", "laser play the french robots")
+	}()
+	<-llm.started
+
+	trace, ok := svc.explain(context.Background(), "", "laser play the french robots")
+	if !ok || trace.Command.Text != "!play the french robots" || trace.Branch != BranchPassthrough {
+		t.Errorf("explain at the limit = (%q, %s), want the raw query", trace.Command.Text, trace.Branch)
+	}
+	if !errors.Is(trace.MatchError, ErrLLMBusy) {
+		t.Errorf("MatchError = %v, want %v", trace.MatchError, ErrLLMBusy)
+	}
+	close(llm.release)
+	<-done
+}
+
+func TestLLMConcurrency_WaitRespectsContext(t *testing.T) {
+	llm := &gatedLLM{reply: "Daft Punk", release: make(chan struct{}), started: make(chan struct{})}
+	svc := newLimitedService(llm, 1, LLMLimitWait)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.explain(context.Background(), "", "laser play the french robots")
+	}()
+	<-llm.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	trace, _ := svc.explain(ctx, "", "laser play the french robots")
+	if !errors.Is(trace.MatchError, context.DeadlineExceeded) || trace.Branch != BranchPassthrough {
+		t.Errorf("explain while waiting = (%s, %v), want passthrough after the deadline", trace.Branch, trace.MatchError)
+	}
+	close(llm.release)
+	<-done
+}
@@ -0,0 +1,28 @@
+package application
+
+import "github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+
+// SetLLMEnabled turns LLM matching of play queries off or back on without
+// replacing the service, e.g. during an LLM outage. While it is off, play
+// queries are handled as if no LLM were configured: matched locally if local
+// or phonetic matching is on, and otherwise sent as spoken. Every other
+// setting is kept. The LLM starts enabled. It is safe to call while voice
+// clips are being handled.
+func (s *VoiceService) SetLLMEnabled(enabled bool) {
+	s.llmOff.Store(!enabled)
+}
+
+// LLMEnabled reports whether play queries may be matched with the LLM. It is
+// false if the LLM was disabled with SetLLMEnabled or none is configured.
+func (s *VoiceService) LLMEnabled() bool {
+	return s.activeLLM() != nil
+}
+
+// activeLLM returns the LLM to match play queries with, or nil if there is
+// none or it is disabled.
+func (s *VoiceService) activeLLM() bot.LLMService {
+	if s.llmOff.Load() {
+		return nil
+	}
+	return s.llm
+}
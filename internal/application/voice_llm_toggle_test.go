@@ -0,0 +1,59 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestSetLLMEnabled(t *testing.T) {
+	llm := &countingLLM{reply: "Daft Punk"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Daft Punk"}, {Name: "Justice"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+	svc.SetCommandPrefix("/")
+
+	svc.SetLLMEnabled(false)
+	if svc.LLMEnabled() {
+		t.Error("LLMEnabled() = true after disabling")
+	}
+	if got := parse(t, svc, "laser play the french robots"); got != "/play the french robots" {
+		t.Errorf("disabled = %q, want the query passed through", got)
+	}
+	if llm.calls != 0 {
+		t.Fatalf("LLM called %d times while disabled, want 0", llm.calls)
+	}
+
+	svc.SetLLMEnabled(true)
+	if !svc.LLMEnabled() {
+		t.Error("LLMEnabled() = false after re-enabling")
+	}
+	if got := parse(t, svc, "laser play the french robots"); got != "/play Daft Punk" {
+		t.Errorf("re-enabled = %q, want the LLM's pick with settings kept", got)
+	}
+	if llm.calls != 1 {
+		t.Errorf("LLM called %d times after re-enabling, want 1", llm.calls)
+	}
+}
+
+func TestSetLLMEnabled_LocalMatchingWhileDisabled(t *testing.T) {
+	llm := &countingLLM{reply: "Justice"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}, {Name: "Justice"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+	svc.SetLocalMatching(true)
+	svc.SetLLMEnabled(false)
+
+	trace, ok := svc.Explain(t.Context(), "laser play its working")
+	if !ok || trace.Command.Text != "!play itsworking" || trace.Branch != BranchLocal {
+		t.Errorf("Explain = (%q, %s), want a local match", trace.Command.Text, trace.Branch)
+	}
+	if llm.calls != 0 {
+		t.Errorf("LLM called %d times while disabled, want 0", llm.calls)
+	}
+}
+
+func TestLLMEnabled_WithoutLLM(t *testing.T) {
+	svc := newTestService()
+	if svc.LLMEnabled() {
+		t.Error("LLMEnabled() = true with no LLM configured")
+	}
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// LLMUsageHook is told about each LLM call VoiceService makes to match a play
+// query, with the messages sent and the reply, e.g. to estimate token spend
+// with your own tokenizer. The reply is empty if the call failed. The hook may
+// be called concurrently and must not modify messages.
+type LLMUsageHook func(messages []bot.LLMMessage, reply string)
+
+// SetLLMUsageHook sets the hook called after every LLM call. Keyword commands
+// and play queries resolved without the LLM don't call it. A nil hook removes it.
+func (s *VoiceService) SetLLMUsageHook(hook LLMUsageHook) {
+	s.llmUsage = hook
+}
+
+// chatCompletion asks llm for a reply within the concurrency limit and
+// reports the call to the usage hook. A dry run takes no slot and isn't
+// reported.
+func (s *VoiceService) chatCompletion(ctx context.Context, llm bot.LLMService, messages []bot.LLMMessage) (string, error) {
+	if isDryRun(ctx) {
+		return llm.ChatCompletion(ctx, messages)
+	}
+	release, err := s.acquireLLM(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	reply, err := llm.ChatCompletion(ctx, messages)
+	if s.llmUsage != nil {
+		if err != nil {
+			s.llmUsage(messages, "")
+		} else {
+			s.llmUsage(messages, reply)
+		}
+	}
+	return reply, err
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// usageCounter records LLM usage hook calls.
+type usageCounter struct {
+	mu      sync.Mutex
+	calls   int
+	replies []string
+	sizes   []int // characters sent per call
+}
+
+func (c *usageCounter) hook(messages []bot.LLMMessage, reply string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls++
+	c.replies = append(c.replies, reply)
+	size := 0
+	for _, m := range messages {
+		size += len(m.Content)
+	}
+	c.sizes = append(c.sizes, size)
+}
+
+func TestLLMUsageHook(t *testing.T) {
+	stt := &mockSTT{}
+	llm := &mockLLM{reply: "Daft Punk"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Daft Punk"}, {Name: "Justice"}}}
+	svc := NewVoiceService(stt, "laser", llm, opts)
+	usage := &usageCounter{}
+	svc.SetLLMUsageHook(usage.hook)
+
+	for _, input := range []string{"laser stop", "laser skip", "laser play random", "laser volume 50", "laser search daft punk"} {
+		handle(t, svc, stt, "u1", input)
+	}
+	if usage.calls != 0 {
+		t.Fatalf("hook called %d times for keyword commands, want 0", usage.calls)
+	}
+
+	if got := handle(t, svc, stt, "u1", "laser play the french robots"); got != "!play Daft Punk" {
+		t.Fatalf("play = %q, want %q", got, "!play Daft Punk")
+	}
+	if usage.calls != 1 || usage.replies[0] != "Daft Punk" || usage.sizes[0] == 0 {
+		t.Errorf("after play, hook calls = %d, replies = %q, sizes = %v; want one call with the reply", usage.calls, usage.replies, usage.sizes)
+	}
+
+	llm.err = errors.New("rate limited")
+	handle(t, svc, stt, "u1", "laser play the other french duo")
+	if usage.calls != 2 || usage.replies[1] != "" {
+		t.Errorf("after failed call, hook calls = %d, replies = %q; want a second call with no reply", usage.calls, usage.replies)
+	}
+}
+
+func TestLLMUsageHook_NoLLM(t *testing.T) {
+	stt := &mockSTT{}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Daft Punk"}}}
+	svc := NewVoiceService(stt, "laser", nil, opts)
+	svc.SetLocalMatching(true)
+	usage := &usageCounter{}
+	svc.SetLLMUsageHook(usage.hook)
+
+	handle(t, svc, stt, "u1", "laser play daft punk")
+	if usage.calls != 0 {
+		t.Errorf("hook called %d times for local matching, want 0", usage.calls)
+	}
+}
@@ -0,0 +1,123 @@
+package application
+
+import (
+	"log"
+	"strings"
+	"unicode"
+)
+
+// minLocalMatchScore is the similarity from 0 to 1 an option needs before the
+// local matcher picks it over passing the query through.
+const minLocalMatchScore = 0.75
+
+// SetLocalMatching enables a local fuzzy matcher that picks the closest play
+// option when no LLM is configured or the LLM call fails, instead of passing
+// the query through. "its working" then still selects "itsworking".
+func (s *VoiceService) SetLocalMatching(enabled bool) {
+	s.localMatch = enabled
+}
+
+// matchLocal returns the indexed option most similar to the query, or the
+// query itself if no option is similar enough.
+func (s *VoiceService) matchLocal(query string, index *optionIndex) (string, MatchBranch) {
+	if i, ok := index.closest(query); ok {
+		name := index.options[i].Name
+		log.Printf("locally matched %q -> %q", query, name)
+		return name, BranchLocal
+	}
+	return query, BranchPassthrough
+}
+
+// closest returns the index of the option most similar to query. Similarity is
+// the better of two scores: edit distance between the names with spaces and
+// punctuation removed, which catches STT splitting or joining words, and word
+// overlap, which catches reordered or partial titles. Earlier options win ties.
+func (idx *optionIndex) closest(query string) (int, bool) {
+	squashed := squashName(query)
+	words := nameWords(query)
+	if squashed == "" {
+		return 0, false
+	}
+
+	best, bestScore := -1, 0.0
+	for i := range idx.options {
+		score := max(editSimilarity(squashed, idx.squashed[i]), wordOverlap(words, idx.words[i]))
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 || bestScore < minLocalMatchScore {
+		return 0, false
+	}
+	return best, true
+}
+
+// squashName lowercases a name and keeps only its letters and digits.
+func squashName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// nameWords splits a name into lowercase words of letters and digits.
+func nameWords(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editSimilarity is 1 minus the Levenshtein distance between a and b divided by
+// the length of the longer one.
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the number of single-rune edits that turn a into b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// wordOverlap is the Dice coefficient of two word lists: twice the number of
+// shared words over the total number of words.
+func wordOverlap(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	remaining := make(map[string]int, len(b))
+	for _, w := range b {
+		remaining[w]++
+	}
+	shared := 0
+	for _, w := range a {
+		if remaining[w] > 0 {
+			remaining[w]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
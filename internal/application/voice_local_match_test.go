@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func localOptions() []bot.PlayOption {
+	return []bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "Around the World"},
+		{Name: "Harder Better Faster Stronger"},
+		{Name: "Mr. Brightside"},
+		{Name: "airhorn"},
+	}
+}
+
+func TestLocalMatching(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, &mockPlayOptions{options: localOptions()})
+	svc.SetLocalMatching(true)
+
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		branch MatchBranch
+	}{
+		{"split words", "laser play its working", "!play itsworking", BranchLocal},
+		{"case and spacing", "laser play around the world", "!play Around the World", BranchSubstring},
+		{"misspelling", "laser play mister brightside", "!play Mr. Brightside", BranchLocal},
+		{"partial title", "laser play harder better faster", "!play Harder Better Faster Stronger", BranchLocal},
+		{"near miss", "laser play air horn", "!play airhorn", BranchLocal},
+		{"no close option", "laser play bohemian rhapsody", "!play bohemian rhapsody", BranchPassthrough},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace, ok := svc.Explain(context.Background(), tt.input)
+			if !ok {
+				t.Fatalf("Explain(%q) returned no match", tt.input)
+			}
+			if trace.Command.Text != tt.want || trace.Branch != tt.branch {
+				t.Errorf("Explain(%q) = (%q, %s), want (%q, %s)",
+					tt.input, trace.Command.Text, trace.Branch, tt.want, tt.branch)
+			}
+		})
+	}
+}
+
+func TestLocalMatching_DisabledByDefault(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, &mockPlayOptions{options: localOptions()})
+
+	if got := parse(t, svc, "laser play its working"); got != "!play its working" {
+		t.Errorf("parse = %q, want passthrough without local matching", got)
+	}
+}
+
+func TestLocalMatching_LLMFailure(t *testing.T) {
+	llm := &mockLLM{err: errors.New("rate limited")}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, &mockPlayOptions{options: localOptions()})
+	svc.SetLocalMatching(true)
+
+	if got := parse(t, svc, "laser play its working"); got != "!play itsworking" {
+		t.Errorf("parse = %q, want local match after LLM failure", got)
+	}
+}
+
+func TestLocalMatching_PrefersLLM(t *testing.T) {
+	llm := &mockLLM{reply: "airhorn"}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, &mockPlayOptions{options: localOptions()})
+	svc.SetLocalMatching(true)
+
+	if got := parse(t, svc, "laser play its working"); got != "!play airhorn" {
+		t.Errorf("parse = %q, want the LLM's choice", got)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"itsworking", "itsworking", 0},
+		{"café", "cafe", 1},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package application
+
+// Logger receives structured events from VoiceService at its decision points,
+// as a message followed by alternating keys and values. *slog.Logger satisfies
+// it; other logging libraries need a small adapter.
+type Logger interface {
+	Debug(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Warn(msg string, keysAndValues ...any)
+}
+
+// nopLogger discards all events. It is the default Logger.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+
+// SetLogger sets the logger for structured voice events: transcriptions, wake
+// phrase matches, matched commands, LLM matching and errors. A nil logger
+// discards them (the default). The plain log output is unaffected.
+func (s *VoiceService) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = nopLogger{}
+	}
+	s.logger = logger
+}
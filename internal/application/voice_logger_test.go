@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// captureLogger records each event as "level: message".
+type captureLogger struct {
+	events []string
+	fields []map[string]any
+}
+
+func (l *captureLogger) record(level, msg string, kv []any) {
+	fields := make(map[string]any, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		fields[fmt.Sprint(kv[i])] = kv[i+1]
+	}
+	l.events = append(l.events, level+": "+msg)
+	l.fields = append(l.fields, fields)
+}
+
+func (l *captureLogger) Debug(msg string, kv ...any) { l.record("debug", msg, kv) }
+func (l *captureLogger) Info(msg string, kv ...any)  { l.record("info", msg, kv) }
+func (l *captureLogger) Warn(msg string, kv ...any)  { l.record("warn", msg, kv) }
+
+// field returns a field of the first event with the given level and message.
+func (l *captureLogger) field(event, key string) any {
+	i := slices.Index(l.events, event)
+	if i < 0 {
+		return nil
+	}
+	return l.fields[i][key]
+}
+
+func TestLogger_HandleVoiceEvents(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", &mockLLM{reply: "itsworking"}, opts)
+	logger := &captureLogger{}
+	svc.SetLogger(logger)
+
+	if got := handle(t, svc, stt, "u1", "hey lazer play its working"); got != "!play itsworking" {
+		t.Fatalf("HandleVoice = %q, want %q", got, "!play itsworking")
+	}
+
+	want := []string{
+		"info: transcription received",
+		"debug: LLM matched play option",
+		"debug: wake phrase matched",
+		"info: command matched",
+	}
+	if !slices.Equal(logger.events, want) {
+		t.Fatalf("events = %q, want %q", logger.events, want)
+	}
+	if got := logger.field("info: transcription received", "text"); got != "hey lazer play its working" {
+		t.Errorf("transcription text = %v", got)
+	}
+	if got := logger.field("debug: wake phrase matched", "wake"); got != "lazer" {
+		t.Errorf("wake = %v, want lazer", got)
+	}
+	if got := logger.field("info: command matched", "text"); got != "!play itsworking" {
+		t.Errorf("command text = %v, want !play itsworking", got)
+	}
+	if got := logger.field("info: command matched", "branch"); got != string(BranchLLM) {
+		t.Errorf("branch = %v, want %v", got, BranchLLM)
+	}
+}
+
+func TestLogger_NoCommandAndErrors(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	logger := &captureLogger{}
+	svc.SetLogger(logger)
+
+	handle(t, svc, stt, "u1", "what a nice day")
+	if got := logger.field("debug: no command", "outcome"); got != string(OutcomeNoWakePhrase) {
+		t.Errorf("no command outcome = %v, want %q (events %q)", got, OutcomeNoWakePhrase, logger.events)
+	}
+
+	sttErr := errors.New("stt down")
+	stt.err = sttErr
+	if _, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio")); !errors.Is(err, sttErr) {
+		t.Fatalf("HandleVoice error = %v, want %v", err, sttErr)
+	}
+	if got := logger.field("warn: transcription failed", "error"); got != sttErr {
+		t.Errorf("transcription failed error = %v, want %v (events %q)", got, sttErr, logger.events)
+	}
+}
+
+func TestLogger_NilAndSlog(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	svc.SetLogger(nil)
+	if got := handle(t, svc, stt, "u1", "laser stop"); got != "!stop" {
+		t.Errorf("with nil logger, HandleVoice = %q, want %q", got, "!stop")
+	}
+
+	var slogLogger Logger = slog.New(slog.DiscardHandler)
+	svc.SetLogger(slogLogger)
+	if got := handle(t, svc, stt, "u1", "laser stop"); got != "!stop" {
+		t.Errorf("with slog logger, HandleVoice = %q, want %q", got, "!stop")
+	}
+}
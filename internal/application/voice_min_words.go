@@ -0,0 +1,36 @@
+package application
+
+// SetNoiseWords sets words dropped from everything said after the wake phrase
+// before it is matched, such as hesitation sounds STT transcribes ("um", "uh").
+// They are removed wherever they appear, play queries included, so they should
+// not be words that occur in titles. A wake phrase followed only by noise
+// counts as a bare wake phrase. None are set by default; fillers between the
+// wake phrase and the command are skipped regardless (see SetCommandFillers).
+func (s *VoiceService) SetNoiseWords(words []string) {
+	s.noiseWords = wordSet(words)
+}
+
+// SetMinCommandWords sets how many words must follow the wake phrase, after
+// noise words are dropped, for a command to be matched. Zero or less (the
+// default) places no limit beyond there being a command.
+func (s *VoiceService) SetMinCommandWords(n int) {
+	s.minCommandWords = max(n, 0)
+}
+
+// dropNoise removes the noise words from cw.
+func (s *VoiceService) dropNoise(cw commandWords) commandWords {
+	if len(s.noiseWords) == 0 {
+		return cw
+	}
+	kept := commandWords{
+		words:  make([]string, 0, len(cw.words)),
+		spoken: make([]string, 0, len(cw.words)),
+	}
+	for i, word := range cw.words {
+		if !s.noiseWords[word] {
+			kept.words = append(kept.words, word)
+			kept.spoken = append(kept.spoken, cw.spoken[i])
+		}
+	}
+	return kept
+}
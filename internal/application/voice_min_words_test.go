@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNoiseWords(t *testing.T) {
+	svc := newTestService()
+	svc.SetNoiseWords([]string{"um", "uh", "a"})
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser um stop", "!stop"},
+		{"laser um", ""},
+		{"laser a", ""},
+		{"laser uh um", ""},
+		{"laser play um never gonna give you up", "!play never gonna give you up"},
+		{"laser skip uh", "!skip"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	trace, ok := svc.Explain(context.Background(), "laser um")
+	if ok || !trace.WakeFound {
+		t.Errorf("Explain(%q) = %+v, %v; want the wake phrase found and no command", "laser um", trace, ok)
+	}
+}
+
+func TestMinCommandWords(t *testing.T) {
+	svc := newTestService()
+	svc.SetNoiseWords([]string{"um"})
+	svc.SetMinCommandWords(2)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser stop", ""},
+		{"laser um stop", ""},
+		{"laser next song", "!skip"},
+		{"laser play random", "!pr"},
+		{"laser um play random", "!pr"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	svc.SetMinCommandWords(0)
+	if got := parse(t, svc, "laser stop"); got != "!stop" {
+		t.Errorf("without a minimum, parse = %q, want %q", got, "!stop")
+	}
+}
+
+func TestNoiseWords_BareWakeBuffered(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newWakeBufferService(stt)
+	svc.SetNoiseWords([]string{"um"})
+
+	if got := handle(t, svc, stt, "u1", "laser um"); got != "" {
+		t.Fatalf("bare wake = %q, want nothing yet", got)
+	}
+	if got := handle(t, svc, stt, "u1", "skip"); got != "!skip" {
+		t.Errorf("follow-up = %q, want %q", got, "!skip")
+	}
+}
@@ -0,0 +1,32 @@
+package application
+
+import "slices"
+
+// negations are the words that negate a command spoken right after them, as
+// they arrive with apostrophes stripped ("don't" becomes "dont").
+var negations = splitPhrases([]string{"dont", "do not", "never", "not"})
+
+// negationBefore returns how many of the words just before index i form a
+// negation, or zero if they don't.
+func negationBefore(words []string, i int) int {
+	for _, neg := range negations {
+		if n := len(neg); i >= n && slices.Equal(words[i-n:i], neg) {
+			return n
+		}
+	}
+	return 0
+}
+
+// negatedCommand reports whether cw is a command keyword preceded by a
+// negation, as in "don't stop" or "do not play random", which must not run the
+// command. "Never mind" is a command of its own and isn't a negation.
+func (s *VoiceService) negatedCommand(cw commandWords) bool {
+	text, offsets := cw.text(), wordOffsets(cw.words)
+	for _, neg := range negations {
+		n := len(neg)
+		if len(cw.words) > n && slices.Equal(cw.words[:n], neg) && s.startsCommand(text[offsets[n]:]) {
+			return !s.startsCommand(text)
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNegatedCommands(t *testing.T) {
+	svc := newTestService()
+	svc.SetAllowTrailingWake(true)
+
+	tests := []string{
+		"laser don't stop",
+		"laser do not stop",
+		"laser never skip",
+		"laser please don't pause",
+		"laser do not play random",
+		"laser don't surprise me",
+		"don't stop laser",
+	}
+	for _, input := range tests {
+		trace, ok := svc.Explain(context.Background(), input)
+		if ok {
+			t.Errorf("%q produced %q, want no command", input, trace.Command.Text)
+			continue
+		}
+		if trace.Reason != RejectNegated {
+			t.Errorf("%q reason = %q, want %q", input, trace.Reason, RejectNegated)
+		}
+	}
+}
+
+func TestNegatedCommands_StrictAdjacency(t *testing.T) {
+	svc := newTestService()
+	svc.SetStrictWakeAdjacency(true)
+
+	if got := parse(t, svc, "laser don't stop"); got != "" {
+		t.Errorf("negated stop in strict mode = %q, want no command", got)
+	}
+}
+
+func TestNegatedCommands_NotNegations(t *testing.T) {
+	svc := newTestService()
+	svc.SetMaxInterveningWords(2)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser never mind", "!cancel"},
+		{"laser stop", "!stop"},
+		{"laser play don't stop me now", "!play don't stop me now"},
+		{"laser play never gonna give you up", "!play never gonna give you up"},
+		{"laser could you stop", "!stop"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("%q = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
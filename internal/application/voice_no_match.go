@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"strings"
+)
+
+// Reasons returned by NoMatchReason.
+const (
+	noMatchNoSpeech      = "nothing was said"
+	noMatchNoWake        = "no wake phrase found"
+	noMatchNoCommand     = "wake phrase found but no command after it"
+	noMatchTooFewWords   = "too few words after the wake phrase"
+	noMatchUnrecognized  = "command keyword not recognized"
+	noMatchRefusedPrefix = "command recognized but refused: "
+)
+
+// NoMatchReason explains in words why a transcription produces no command,
+// e.g. "no wake phrase found", for building help responses. A recognized
+// command that was refused is explained with its RejectReason. Returns "" if
+// the transcription produces a command. Like Explain, it may consult play
+// options and the LLM.
+func (s *VoiceService) NoMatchReason(ctx context.Context, transcription string) string {
+	trace, ok := s.explainMatched(withDryRun(ctx), transcription)
+
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	switch {
+	case ok:
+		return ""
+	case trace.Reason != "":
+		return noMatchRefusedPrefix + string(trace.Reason)
+	case !trace.WakeFound:
+		if len(newCommandWords(strings.Fields(transcription)).words) == 0 {
+			return noMatchNoSpeech
+		}
+		return noMatchNoWake
+	}
+
+	rest, _, _ := s.commandText(s.wakePhraseFor(""), transcription)
+	rest = s.dropNoise(rest)
+	switch {
+	case s.onlyFillers(rest.words):
+		return noMatchNoCommand
+	case len(rest.words) < s.minCommandWords:
+		return noMatchTooFewWords
+	default:
+		return noMatchUnrecognized
+	}
+}
+
+// onlyFillers reports whether every word is a command filler, as in "laser um".
+func (s *VoiceService) onlyFillers(words []string) bool {
+	for _, w := range words {
+		if !s.commandFillers[w] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNoMatchReason(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		// The inputs of TestWakePhrase_NoMatch.
+		{"no wake phrase", "stop the music", noMatchNoWake},
+		{"play without wake", "play something", noMatchNoWake},
+		{"blazer not laser", "blazer stop", noMatchNoWake},
+		{"empty", "", noMatchNoSpeech},
+		{"just filler", "hey yo", noMatchNoWake},
+		{"wake phrase buried too deep", "i was just saying hey laser stop", noMatchNoWake},
+		{"partial word", "lasers stop", noMatchNoWake},
+
+		{"punctuation only", " ... ", noMatchNoSpeech},
+		{"just laser", "laser", noMatchNoCommand},
+		{"hey laser", "hey laser", noMatchNoCommand},
+		{"wake phrase and filler", "laser um", noMatchNoCommand},
+		{"unknown command", "laser make me a sandwich", noMatchUnrecognized},
+		{"near keyword", "laser stob", noMatchUnrecognized},
+		{"matches", "laser stop", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := svc.NoMatchReason(context.Background(), tt.input); got != tt.want {
+				t.Errorf("NoMatchReason(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoMatchReason_Refused(t *testing.T) {
+	svc := newTestService()
+	svc.SetPlaybackState(&mockPlayback{})
+
+	want := "command recognized but refused: " + string(RejectNothingPlaying)
+	if got := svc.NoMatchReason(context.Background(), "laser skip"); got != want {
+		t.Errorf("NoMatchReason = %q, want %q", got, want)
+	}
+}
+
+func TestNoMatchReason_TooFewWords(t *testing.T) {
+	svc := newTestService()
+	svc.SetMinCommandWords(2)
+
+	if got := svc.NoMatchReason(context.Background(), "laser stop"); got != noMatchTooFewWords {
+		t.Errorf("NoMatchReason = %q, want %q", got, noMatchTooFewWords)
+	}
+}
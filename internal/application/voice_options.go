@@ -0,0 +1,145 @@
+package application
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// optionIndex looks up play options by normalized name. Names are normalized
+// once when the index is built rather than on every match.
+type optionIndex struct {
+	options  []bot.PlayOption
+	byName   map[string]bot.PlayOption
+	squashed []string   // per option, for local matching
+	words    [][]string // per option, for local matching
+
+	byPhonetic map[string]int // phonetic key → option index, -1 if shared
+}
+
+// newOptionIndex indexes the options. When several normalize to the same
+// name, the first one wins.
+func newOptionIndex(options []bot.PlayOption) *optionIndex {
+	idx := &optionIndex{
+		options:  options,
+		byName:   make(map[string]bot.PlayOption, len(options)),
+		squashed: make([]string, len(options)),
+		words:    make([][]string, len(options)),
+
+		byPhonetic: make(map[string]int, len(options)),
+	}
+	for i, opt := range options {
+		idx.squashed[i] = squashName(opt.Name)
+		idx.words[i] = nameWords(opt.Name)
+		key := normalizeOptionName(opt.Name)
+		if _, exists := idx.byName[key]; !exists {
+			idx.byName[key] = opt
+		}
+		if sound := phoneticKey(opt.Name); sound != "" {
+			if _, shared := idx.byPhonetic[sound]; shared {
+				idx.byPhonetic[sound] = -1
+			} else {
+				idx.byPhonetic[sound] = i
+			}
+		}
+	}
+	return idx
+}
+
+// find returns the option whose name matches name, ignoring case and
+// differences in whitespace.
+func (idx *optionIndex) find(name string) (bot.PlayOption, bool) {
+	opt, ok := idx.byName[normalizeOptionName(name)]
+	return opt, ok
+}
+
+// containedIn returns the option whose name appears in query as a run of whole
+// words, ignoring case and punctuation, so "the itsworking track" names
+// "itsworking". When several do, the longest name wins, and names shorter than
+// minFuzzyWordLength letters are skipped so words like "up" don't match every
+// query.
+func (idx *optionIndex) containedIn(query string) (bot.PlayOption, bool) {
+	queryWords := nameWords(query)
+	best := -1
+	for i, words := range idx.words {
+		if len(idx.squashed[i]) < minFuzzyWordLength || !containsRun(queryWords, words) {
+			continue
+		}
+		if best < 0 || len(idx.squashed[i]) > len(idx.squashed[best]) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return bot.PlayOption{}, false
+	}
+	return idx.options[best], true
+}
+
+// containsRun reports whether run appears contiguously in words.
+func containsRun(words, run []string) bool {
+	if len(run) == 0 {
+		return false
+	}
+	for i := 0; i+len(run) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(run)], run) {
+			return true
+		}
+	}
+	return false
+}
+
+// covers reports whether the index was built from the same option names.
+func (idx *optionIndex) covers(options []bot.PlayOption) bool {
+	if len(idx.options) != len(options) {
+		return false
+	}
+	for i, opt := range options {
+		if idx.options[i].Name != opt.Name {
+			return false
+		}
+	}
+	return true
+}
+
+// optionIndexFor returns an index for the options, reusing the previous one
+// when the fetched list hasn't changed. Sources like the cached API client
+// return the same list until it's refreshed.
+func (s *VoiceService) optionIndexFor(options []bot.PlayOption) *optionIndex {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	if s.index == nil || !s.index.covers(options) {
+		s.index = newOptionIndex(options)
+	}
+	s.indexAt = s.clock.Now()
+	return s.index
+}
+
+// SetOptionCacheTTL lets a play query naming one of the last fetched options
+// be matched without fetching them again, for up to ttl after the fetch. Use
+// the options source's own cache TTL so an option removed from the source
+// stops matching once the source would have dropped it. Zero (the default)
+// always fetches first.
+func (s *VoiceService) SetOptionCacheTTL(ttl time.Duration) {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	s.indexTTL = ttl
+}
+
+// cachedOptionIndex returns the index of the last fetched options, or nil if
+// none were fetched within the option cache TTL.
+func (s *VoiceService) cachedOptionIndex() *optionIndex {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	if s.index == nil || !s.clock.Now().Before(s.indexAt.Add(s.indexTTL)) {
+		return nil
+	}
+	return s.index
+}
+
+// normalizeOptionName lowercases a name and collapses runs of whitespace.
+func normalizeOptionName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
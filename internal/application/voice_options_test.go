@@ -0,0 +1,222 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestOptionIndex_Find(t *testing.T) {
+	idx := newOptionIndex([]bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "Mirage  Wish"},
+		{Name: "mirage wish"},
+	})
+
+	tests := []struct {
+		name   string
+		query  string
+		want   string
+		wantOK bool
+	}{
+		{"exact", "itsworking", "itsworking", true},
+		{"case", "ITSWORKING", "itsworking", true},
+		{"whitespace", "  mirage   wish ", "Mirage  Wish", true},
+		{"first duplicate wins", "mirage wish", "Mirage  Wish", true},
+		{"missing", "never gonna give you up", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := idx.find(tt.query)
+			if ok != tt.wantOK || got.Name != tt.want {
+				t.Errorf("find(%q) = %q, %v, want %q, %v", tt.query, got.Name, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestOptionIndex_ContainedIn(t *testing.T) {
+	idx := newOptionIndex([]bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "Daft Punk"},
+		{Name: "Daft Punk Live"},
+		{Name: "AC/DC"},
+		{Name: "Up"},
+	})
+
+	tests := []struct {
+		query  string
+		want   string
+		wantOK bool
+	}{
+		{"the itsworking track", "itsworking", true},
+		{"ITSWORKING", "itsworking", true},
+		{"some daft punk please", "Daft Punk", true},
+		{"daft punk live at wembley", "Daft Punk Live", true},
+		{"ac dc back in black", "AC/DC", true},
+		{"itsworkingagain", "", false},
+		{"daft punks", "", false},
+		{"give it up", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := idx.containedIn(tt.query)
+		if ok != tt.wantOK || got.Name != tt.want {
+			t.Errorf("containedIn(%q) = %q, %v, want %q, %v", tt.query, got.Name, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// countingLLM counts ChatCompletion calls.
+type countingLLM struct {
+	reply string
+	calls int
+}
+
+func (c *countingLLM) ChatCompletion(_ context.Context, _ []bot.LLMMessage) (string, error) {
+	c.calls++
+	return c.reply, nil
+}
+
+func TestSubstringMatchSkipsLLM(t *testing.T) {
+	llm := &countingLLM{reply: "Justice"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}, {Name: "Justice"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	trace, ok := svc.Explain(context.Background(), "laser play the itsworking track")
+	if !ok || trace.Command.Text != "!play itsworking" || trace.Branch != BranchSubstring {
+		t.Errorf("Explain = (%q, %s), want (%q, %s)", trace.Command.Text, trace.Branch, "!play itsworking", BranchSubstring)
+	}
+	if llm.calls != 0 {
+		t.Errorf("LLM called %d times for a query containing an option, want 0", llm.calls)
+	}
+
+	if got := parse(t, svc, "laser play that french duo"); got != "!play Justice" {
+		t.Errorf("parse = %q, want the LLM's pick", got)
+	}
+	if llm.calls != 1 {
+		t.Errorf("LLM called %d times without a contained option, want 1", llm.calls)
+	}
+}
+
+func TestOptionIndexFor_ReusedUntilOptionsChange(t *testing.T) {
+	svc := newTestService()
+	first := []bot.PlayOption{{Name: "a"}, {Name: "b"}}
+
+	idx := svc.optionIndexFor(first)
+	if again := svc.optionIndexFor([]bot.PlayOption{{Name: "a"}, {Name: "b"}}); again != idx {
+		t.Error("index rebuilt for an identical option list")
+	}
+
+	changed := svc.optionIndexFor([]bot.PlayOption{{Name: "a"}, {Name: "c"}})
+	if changed == idx {
+		t.Fatal("index reused after the option list changed")
+	}
+	if _, ok := changed.find("c"); !ok {
+		t.Error("rebuilt index is missing the new option")
+	}
+}
+
+func benchmarkOptions(n int) []bot.PlayOption {
+	options := make([]bot.PlayOption, n)
+	for i := range options {
+		options[i] = bot.PlayOption{Name: fmt.Sprintf("Option Number %d", i)}
+	}
+	return options
+}
+
+// BenchmarkMatchOption_PerRequest normalizes every option name on each match,
+// as matching did before names were precomputed.
+func BenchmarkMatchOption_PerRequest(b *testing.B) {
+	options := benchmarkOptions(500)
+	b.ReportAllocs()
+	for b.Loop() {
+		newOptionIndex(options).find("option number 250")
+	}
+}
+
+// BenchmarkMatchOption_Precomputed reuses the index across matches against the
+// same option list.
+func BenchmarkMatchOption_Precomputed(b *testing.B) {
+	svc := newTestService()
+	options := benchmarkOptions(500)
+	b.ReportAllocs()
+	for b.Loop() {
+		svc.optionIndexFor(options).find("option number 250")
+	}
+}
+
+func TestGetOptionsOnlyWhenNeeded(t *testing.T) {
+	llm := &countingLLM{reply: "Justice"}
+	opts := &countingOptions{mockPlayOptions: mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}, {Name: "Justice"}}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+	svc.SetOptionCacheTTL(time.Minute)
+
+	for _, input := range []string{"laser stop", "laser volume 50", "laser play random", "laser search daft punk", "laser play my road trip playlist"} {
+		parse(t, svc, input)
+	}
+	if opts.calls != 0 {
+		t.Fatalf("GetOptions called %d times for commands without a play query, want 0", opts.calls)
+	}
+
+	if got := parse(t, svc, "laser play that french duo"); got != "!play Justice" {
+		t.Fatalf("parse = %q, want the LLM's pick", got)
+	}
+	if opts.calls != 1 {
+		t.Fatalf("GetOptions called %d times for an LLM match, want 1", opts.calls)
+	}
+	if got := parse(t, svc, "laser play the itsworking track"); got != "!play itsworking" {
+		t.Fatalf("parse = %q, want the substring match", got)
+	}
+	if opts.calls != 1 || llm.calls != 1 {
+		t.Errorf("after a substring match, GetOptions calls = %d and LLM calls = %d, want both still 1", opts.calls, llm.calls)
+	}
+}
+
+func TestCachedOptionsExpire(t *testing.T) {
+	opts := &countingOptions{mockPlayOptions: mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}, {Name: "Justice"}}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: "NONE"}, opts)
+	clock := newFakeClock()
+	svc.SetClock(clock)
+	svc.SetOptionCacheTTL(time.Minute)
+
+	parse(t, svc, "laser play justice")
+	opts.options = []bot.PlayOption{{Name: "Justice"}}
+	if got := parse(t, svc, "laser play the itsworking track"); got != "!play itsworking" || opts.calls != 1 {
+		t.Fatalf("within the TTL: parse = %q after %d fetches, want the cached option without a fetch", got, opts.calls)
+	}
+
+	clock.Advance(time.Minute)
+	if got := parse(t, svc, "laser play the itsworking track"); got != "!play the itsworking track" {
+		t.Errorf("after the TTL: parse = %q, want the removed option no longer matched", got)
+	}
+	if opts.calls != 2 {
+		t.Errorf("GetOptions called %d times, want 2", opts.calls)
+	}
+}
+
+func TestCachedOptionsDisabledByDefault(t *testing.T) {
+	opts := &countingOptions{mockPlayOptions: mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}, {Name: "Justice"}}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: "NONE"}, opts)
+
+	parse(t, svc, "laser play justice")
+	opts.options = []bot.PlayOption{{Name: "Justice"}}
+	if got := parse(t, svc, "laser play the itsworking track"); got != "!play the itsworking track" {
+		t.Errorf("parse = %q, want the removed option no longer matched", got)
+	}
+}
+
+func TestGetOptionsSkippedWithoutMatcher(t *testing.T) {
+	opts := &countingOptions{mockPlayOptions: mockPlayOptions{options: []bot.PlayOption{{Name: "Justice"}}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, opts)
+
+	if got := parse(t, svc, "laser play justice"); got != "!play justice" {
+		t.Errorf("parse = %q, want the query passed through", got)
+	}
+	if opts.calls != 0 {
+		t.Errorf("GetOptions called %d times with nothing to match against, want 0", opts.calls)
+	}
+}
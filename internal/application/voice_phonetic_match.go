@@ -0,0 +1,70 @@
+package application
+
+import "strings"
+
+// soundexCodes groups consonants that sound alike, as in Soundex. Letters
+// without a code (vowels, h, w, y) separate repeated codes; "h" and "w" don't.
+var soundexCodes = map[rune]byte{
+	'b': '1', 'f': '1', 'p': '1', 'v': '1',
+	'c': '2', 'g': '2', 'j': '2', 'k': '2', 'q': '2', 's': '2', 'x': '2', 'z': '2',
+	'd': '3', 't': '3',
+	'l': '4',
+	'm': '5', 'n': '5',
+	'r': '6',
+}
+
+// minPhoneticSounds is the shortest phonetic code that is matched; shorter
+// codes like "Toto"'s match too many ordinary words ("today").
+const minPhoneticSounds = 3
+
+// SetPhoneticMatching picks the play option that sounds like the query, or
+// like a run of its words, before asking the LLM, so "play the fotograf song"
+// finds "Photograph". Names are compared by a Soundex-style code of every
+// letter, first included, so it catches misspellings that keep the consonants.
+// An option is only picked when no other option sounds the same.
+func (s *VoiceService) SetPhoneticMatching(enabled bool) {
+	s.phonetic = enabled
+}
+
+// phoneticKey returns the Soundex-style code of a name's letters and digits,
+// not truncated, e.g. "Photograph" and "fotograf" both give "13261". Digits
+// are kept as they are.
+func phoneticKey(name string) string {
+	var b strings.Builder
+	var last byte
+	for _, r := range squashName(name) {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+			last = 0
+			continue
+		}
+		code, ok := soundexCodes[r]
+		switch {
+		case !ok && r != 'h' && r != 'w':
+			last = 0
+		case ok && code != last:
+			b.WriteByte(code)
+			last = code
+		}
+	}
+	return b.String()
+}
+
+// soundsLike returns the option whose name sounds like the query or a run of
+// its words, preferring the longest run. Options sharing a code with another
+// option, and codes shorter than minPhoneticSounds, are never picked.
+func (idx *optionIndex) soundsLike(query string) (int, bool) {
+	words := nameWords(query)
+	for n := len(words); n > 0; n-- {
+		for start := 0; start+n <= len(words); start++ {
+			key := phoneticKey(strings.Join(words[start:start+n], ""))
+			if len(key) < minPhoneticSounds {
+				continue
+			}
+			if i, ok := idx.byPhonetic[key]; ok && i >= 0 {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestPhoneticKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Photograph", "fotograf"},
+		{"miragewish", "mirage wish"},
+		{"Smells Like Teen Spirit", "smels like tean spirrit"},
+	}
+	for _, tt := range tests {
+		if ka, kb := phoneticKey(tt.a), phoneticKey(tt.b); ka != kb {
+			t.Errorf("phoneticKey(%q) = %q, phoneticKey(%q) = %q, want equal", tt.a, ka, tt.b, kb)
+		}
+	}
+	if ka, kb := phoneticKey("Photograph"), phoneticKey("Paragraph"); ka == kb {
+		t.Errorf("phoneticKey(Photograph) = phoneticKey(Paragraph) = %q, want different", ka)
+	}
+}
+
+func TestPhoneticMatching(t *testing.T) {
+	llm := &countingLLM{reply: "Levitating"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "Photograph"}, {Name: "miragewish"}, {Name: "Levitating"}, {Name: "Toto"},
+	}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+	svc.SetPhoneticMatching(true)
+
+	tests := []struct {
+		input  string
+		want   string
+		branch MatchBranch
+	}{
+		{"laser play fotograf", "!play Photograph", BranchPhonetic},
+		{"laser play the fotograf song", "!play Photograph", BranchPhonetic},
+		{"laser play mirage wich", "!play miragewish", BranchPhonetic},
+		{"laser play today", "!play Levitating", BranchLLM},
+	}
+	for _, tt := range tests {
+		trace, ok := svc.Explain(context.Background(), tt.input)
+		if !ok || trace.Command.Text != tt.want || trace.Branch != tt.branch {
+			t.Errorf("Explain(%q) = (%q, %s), want (%q, %s)", tt.input, trace.Command.Text, trace.Branch, tt.want, tt.branch)
+		}
+	}
+	if llm.calls != 1 {
+		t.Errorf("LLM called %d times, want once for the query that sounds like nothing", llm.calls)
+	}
+}
+
+func TestPhoneticMatching_WithoutLLM(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Photograph"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, opts)
+
+	if got := parse(t, svc, "laser play fotograf"); got != "!play fotograf" {
+		t.Errorf("parse with phonetic matching off = %q, want passthrough", got)
+	}
+	svc.SetPhoneticMatching(true)
+	if got := parse(t, svc, "laser play fotograf"); got != "!play Photograph" {
+		t.Errorf("parse = %q, want %q", got, "!play Photograph")
+	}
+}
+
+func TestPhoneticMatching_Ambiguous(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "Photograph"}, {Name: "Fotograf"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", nil, opts)
+	svc.SetPhoneticMatching(true)
+
+	if trace, _ := svc.Explain(context.Background(), "laser play photograff"); trace.Command.Text != "!play photograff" {
+		t.Errorf("Explain = (%q, %s), want passthrough when two options sound alike", trace.Command.Text, trace.Branch)
+	}
+}
@@ -0,0 +1,42 @@
+package application
+
+import "strings"
+
+// defaultPlaceholderTokens are the tokens STT providers insert for sounds that
+// aren't speech, plus the most common hesitation sounds.
+var defaultPlaceholderTokens = []string{
+	"[inaudible]", "(inaudible)", "[noise]", "[music]", "[laughter]", "[blank_audio]", "uh", "um",
+}
+
+// SetPlaceholderTokens replaces the tokens dropped from a transcription before
+// it is parsed, such as "[inaudible]" or "[noise]", so "laser [inaudible] stop"
+// still stops. Tokens are whole words matched case-insensitively, ignoring
+// trailing punctuation, and are dropped wherever they appear, before the wake
+// phrase and in play queries too. Passing nil turns dropping off.
+func (s *VoiceService) SetPlaceholderTokens(tokens []string) {
+	s.placeholders = wordSet(tokens)
+}
+
+// AddPlaceholderTokens adds tokens to those dropped from transcriptions,
+// keeping the defaults.
+func (s *VoiceService) AddPlaceholderTokens(tokens ...string) {
+	for token := range wordSet(tokens) {
+		s.placeholders[token] = true
+	}
+}
+
+// transcriptWords splits the transcription into fields like transcriptFields
+// and drops the placeholder tokens.
+func (s *VoiceService) transcriptWords(transcription string) []string {
+	fields := transcriptFields(transcription)
+	if len(s.placeholders) == 0 {
+		return fields
+	}
+	kept := fields[:0]
+	for _, f := range fields {
+		if !s.placeholders[strings.ToLower(strings.TrimRight(f, ".!?;:"))] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,55 @@
+package application
+
+import "testing"
+
+func TestPlaceholderTokens(t *testing.T) {
+	svc := newTestService()
+	svc.SetStrictWakeAdjacency(true)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser [inaudible] stop", "!stop"},
+		{"Laser [Noise] [music] skip.", "!skip"},
+		{"[noise] laser stop", "!stop"},
+		{"laser, [inaudible], pause", "!pause"},
+		{"laser (inaudible) stop [noise].", "!stop"},
+		{"laser uh play [inaudible] Daft Punk", "!play Daft Punk"},
+		{"laser [applause] stop", ""},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPlaceholderTokens_Custom(t *testing.T) {
+	svc := newTestService()
+	svc.SetStrictWakeAdjacency(true)
+	svc.AddPlaceholderTokens("[Applause]", " <unk> ")
+
+	for _, input := range []string{"laser [applause] stop", "laser <unk> stop", "laser [inaudible] stop"} {
+		if got := parse(t, svc, input); got != "!stop" {
+			t.Errorf("parse(%q) = %q, want %q", input, got, "!stop")
+		}
+	}
+
+	svc.SetPlaceholderTokens(nil)
+	if got := parse(t, svc, "laser [inaudible] stop"); got != "" {
+		t.Errorf("parse with dropping off = %q, want no match", got)
+	}
+}
+
+func TestPlaceholderTokens_BareWake(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newWakeBufferService(stt)
+
+	if got := handle(t, svc, stt, "u1", "laser [inaudible]"); got != "" {
+		t.Fatalf("wake phrase and placeholder produced %q", got)
+	}
+	if got := handle(t, svc, stt, "u1", "stop"); got != "!stop" {
+		t.Errorf("follow-up = %q, want %q", got, "!stop")
+	}
+}
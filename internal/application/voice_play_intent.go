@@ -0,0 +1,31 @@
+package application
+
+import "slices"
+
+// playVerbs are the phrases that start a play command. "find me" is listed
+// before "find" so "find me a song about rain" queries "a song about rain".
+var playVerbs = []string{"play", "find me", "find", "put on", "throw on", "queue up"}
+
+// playVerbWords holds playVerbs split into words.
+var playVerbWords = splitPhrases(playVerbs)
+
+// playVerbLength returns how many words of the play verb start words, e.g. 2
+// for "put on some jazz".
+func playVerbLength(words []string) (int, bool) {
+	for _, verb := range playVerbWords {
+		if len(words) >= len(verb) && slices.Equal(words[:len(verb)], verb) {
+			return len(verb), true
+		}
+	}
+	return 0, false
+}
+
+// startsPlayVerb reports whether text is a play verb followed by a query.
+func startsPlayVerb(text string) bool {
+	for _, verb := range playVerbs {
+		if len(text) > len(verb) && hasPhrasePrefix(text, verb) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPlayVerbs(t *testing.T) {
+	svc := newTestService()
+	svc.SetMaxInterveningWords(2)
+
+	tests := []struct {
+		input     string
+		wantQuery string
+	}{
+		{"laser play some jazz", "some jazz"},
+		{"laser find me a song about rain", "a song about rain"},
+		{"laser find something by Queen", "something by Queen"},
+		{"laser put on some jazz", "some jazz"},
+		{"laser throw on Daft Punk", "Daft Punk"},
+		{"laser queue up the next episode", "the next episode"},
+		{"laser could you put on some jazz", "some jazz"},
+	}
+	for _, tt := range tests {
+		trace, ok := svc.Explain(context.Background(), tt.input)
+		if !ok {
+			t.Errorf("Explain(%q) matched nothing, want play %q", tt.input, tt.wantQuery)
+			continue
+		}
+		if trace.Command.Name != "play" || trace.Query != tt.wantQuery || trace.Branch != BranchPassthrough {
+			t.Errorf("Explain(%q) = %s %q via %s, want play %q via %s",
+				tt.input, trace.Command.Name, trace.Query, trace.Branch, tt.wantQuery, BranchPassthrough)
+		}
+		if want := "!play " + tt.wantQuery; trace.Command.Text != want {
+			t.Errorf("Explain(%q) text = %q, want %q", tt.input, trace.Command.Text, want)
+		}
+	}
+}
+
+func TestPlayVerbs_RandomAndPlaylist(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser put on something random", "!pr"},
+		{"laser throw on my chill playlist", "!playlist chill"},
+		{"laser stop and put on some jazz", "!stop"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	cmds := svc.ParseCommands(context.Background(), "laser stop and put on some jazz")
+	if len(cmds) != 2 || cmds[1].Text != "!play some jazz" {
+		t.Errorf("ParseCommands = %+v, want stop then play some jazz", cmds)
+	}
+}
+
+func TestPlayVerbs_NoQuery(t *testing.T) {
+	svc := newTestService()
+
+	for _, input := range []string{"laser find", "laser put on", "laser queue up", "laser find me"} {
+		if got := parse(t, svc, input); got != "" {
+			t.Errorf("parse(%q) = %q, want no command", input, got)
+		}
+	}
+}
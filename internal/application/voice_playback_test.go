@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+type mockPlayback struct {
+	playing bool
+	queued  bool
+}
+
+func (m *mockPlayback) IsPlaying() bool { return m.playing }
+func (m *mockPlayback) HasQueue() bool  { return m.queued }
+
+func TestPlaybackControls_BasicCommands(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser skip", "!skip"},
+		{"laser next song", "!skip"},
+		{"laser skip this one", "!skip"},
+		{"laser pause", "!pause"},
+		{"laser pause the music", "!pause"},
+		{"laser resume", "!resume"},
+		{"laser unpause", "!resume"},
+		{"laser keep playing", "!resume"},
+	}
+
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPlaybackState_Gating(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      mockPlayback
+		input      string
+		want       string
+		wantReason RejectReason
+	}{
+		{"stop while playing", mockPlayback{playing: true}, "laser stop", "!stop", ""},
+		{"stop while idle", mockPlayback{}, "laser stop", "", RejectNothingPlaying},
+		{"skip while idle", mockPlayback{queued: true}, "laser skip", "", RejectNothingPlaying},
+		{"pause while idle", mockPlayback{}, "laser pause", "", RejectNothingPlaying},
+		{"pause while playing", mockPlayback{playing: true}, "laser pause", "!pause", ""},
+		{"resume while idle", mockPlayback{}, "laser resume", "!resume", ""},
+		{"clear with empty queue", mockPlayback{playing: true}, "laser clear queue", "", RejectQueueEmpty},
+		{"clear with queue", mockPlayback{queued: true}, "laser clear queue", "!clear", ""},
+		{"play while idle", mockPlayback{}, "laser play some song", "!play some song", ""},
+		{"play random while idle", mockPlayback{}, "laser play random", "!pr", ""},
+		{"cancel while idle", mockPlayback{}, "laser stop that", "!cancel", ""},
+		{"save while idle", mockPlayback{}, "laser save this", "", RejectNothingPlaying},
+		{"save while playing", mockPlayback{playing: true}, "laser save this", "!save", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService()
+			state := tt.state
+			svc.SetPlaybackState(&state)
+
+			trace, ok := svc.Explain(context.Background(), tt.input)
+			got := ""
+			if ok {
+				got = trace.Command.Text
+			}
+			if got != tt.want {
+				t.Errorf("Explain(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if trace.Reason != tt.wantReason {
+				t.Errorf("Explain(%q).Reason = %q, want %q", tt.input, trace.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestPlaybackState_CompoundSkipsRefused(t *testing.T) {
+	svc := newTestService()
+	svc.SetPlaybackState(&mockPlayback{})
+
+	cmds := svc.ParseCommands(context.Background(), "laser stop and play random")
+	if len(cmds) != 1 || cmds[0].Text != "!pr" {
+		t.Errorf("ParseCommands = %+v, want only !pr", cmds)
+	}
+	if got := parse(t, svc, "laser stop and play random"); got != "!pr" {
+		t.Errorf("parse = %q, want %q", got, "!pr")
+	}
+}
+
+func TestPlaybackState_ReasonInResult(t *testing.T) {
+	tests := []struct {
+		input      string
+		state      mockPlayback
+		wantReason RejectReason
+	}{
+		{"laser skip", mockPlayback{queued: true}, RejectNothingPlaying},
+		{"laser stop", mockPlayback{}, RejectNothingPlaying},
+		{"laser pause", mockPlayback{}, RejectNothingPlaying},
+		{"laser clear the queue", mockPlayback{playing: true}, RejectQueueEmpty},
+		{"laser resume", mockPlayback{}, ""},
+		{"laser dance", mockPlayback{}, ""},
+	}
+
+	for _, tt := range tests {
+		stt := &mockSTT{text: tt.input}
+		svc := NewVoiceService(stt, "laser", nil, nil)
+		state := tt.state
+		svc.SetPlaybackState(&state)
+
+		result, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+		if err != nil {
+			t.Fatalf("HandleVoiceDetailed(%q) error: %v", tt.input, err)
+		}
+		if result.Reason != tt.wantReason {
+			t.Errorf("%q reason = %q, want %q", tt.input, result.Reason, tt.wantReason)
+		}
+		if tt.wantReason != "" && (result.Command != "" || result.Outcome != OutcomeNoCommandAfterWake) {
+			t.Errorf("%q = %q (%s), want no command after the wake phrase", tt.input, result.Command, result.Outcome)
+		}
+	}
+}
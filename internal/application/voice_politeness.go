@@ -0,0 +1,58 @@
+package application
+
+import "strings"
+
+// defaultPolitenessPhrases are stripped from the end of play queries.
+var defaultPolitenessPhrases = []string{
+	"please", "pretty please", "thanks", "thank you", "thank you so much",
+	"thanks a lot", "thanks buddy", "cheers",
+}
+
+// SetPolitenessPhrases replaces the phrases stripped from the end of play
+// queries, so "play that song please" searches for "that song".
+func (s *VoiceService) SetPolitenessPhrases(phrases []string) {
+	s.politeness = splitPhrases(phrases)
+}
+
+// splitPhrases lowercases each phrase and splits it into words, skipping blanks.
+func splitPhrases(phrases []string) [][]string {
+	split := make([][]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		if words := strings.Fields(strings.ToLower(phrase)); len(words) > 0 {
+			split = append(split, words)
+		}
+	}
+	return split
+}
+
+// trimPoliteness removes trailing politeness phrases from the words. The longest
+// matching phrase is removed first, and nothing is removed when it would leave
+// the query empty — so a query of just "please" or "pretty please" is kept.
+func (s *VoiceService) trimPoliteness(cw commandWords) commandWords {
+	for {
+		n := 0
+		for _, phrase := range s.politeness {
+			if len(phrase) > n && hasWordSuffix(cw.words, phrase) {
+				n = len(phrase)
+			}
+		}
+		if n == 0 || n == len(cw.words) {
+			return cw
+		}
+		cw = cw.slice(0, len(cw.words)-n)
+	}
+}
+
+// hasWordSuffix reports whether words ends with suffix.
+func hasWordSuffix(words, suffix []string) bool {
+	if len(suffix) > len(words) {
+		return false
+	}
+	offset := len(words) - len(suffix)
+	for i, word := range suffix {
+		if words[offset+i] != word {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package application
+
+import "testing"
+
+func TestPlayCommand_StripsPoliteness(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"please", "laser play that song please", "!play that song"},
+		{"thank you", "laser play Daft Punk thank you", "!play Daft Punk"},
+		{"thanks buddy", "laser play some jazz, thanks buddy!", "!play some jazz"},
+		{"stacked", "laser play some jazz please thanks", "!play some jazz"},
+		{"caps", "laser play Lo Fi PLEASE", "!play Lo Fi"},
+		{"pretty please", "laser play Dua Lipa pretty please", "!play Dua Lipa"},
+		{"only at the tail", "laser play please don't go", "!play please don't go"},
+		{"title ending in please", "laser play Please Please Me", "!play Please Please Me"},
+		{"query is only please", "laser play please", "!play please"},
+		{"title is a politeness phrase", "laser play Pretty Please", "!play Pretty Please"},
+		{"not a whole word", "laser play the pleaser", "!play the pleaser"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayCommand_CustomPoliteness(t *testing.T) {
+	svc := newTestService()
+	svc.SetPolitenessPhrases([]string{"Ta", "  cheers mate "})
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser play some jazz ta", "!play some jazz"},
+		{"laser play some jazz cheers mate", "!play some jazz"},
+		{"laser play some jazz please", "!play some jazz please"},
+	}
+
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// QueryOverflow selects what happens to a play query longer than the maximum.
+type QueryOverflow int
+
+const (
+	// QueryTruncate shortens the query to whole words that fit the maximum.
+	QueryTruncate QueryOverflow = iota
+	// QueryReject refuses the play command.
+	QueryReject
+)
+
+// SetMaxQueryLength limits play queries and playlist names to n characters, so a
+// misheard monologue isn't sent to the music backend. Zero (the default) means
+// unlimited. SetQueryOverflow chooses whether longer queries are truncated or
+// rejected.
+func (s *VoiceService) SetMaxQueryLength(n int) {
+	s.maxQuery = n
+}
+
+// SetQueryOverflow sets how queries over the maximum length are handled
+// (default QueryTruncate).
+func (s *VoiceService) SetQueryOverflow(mode QueryOverflow) {
+	s.queryOverflow = mode
+}
+
+// limitQuery applies the maximum query length. Returns false if the query is
+// too long and overflowing queries are rejected.
+func (s *VoiceService) limitQuery(query string) (string, bool) {
+	if s.maxQuery <= 0 || utf8.RuneCountInString(query) <= s.maxQuery {
+		return query, true
+	}
+	if s.queryOverflow == QueryReject {
+		return "", false
+	}
+	return truncateWords(query, s.maxQuery), true
+}
+
+// truncateWords shortens text to at most max characters, cutting at the last
+// word boundary that fits. A first word longer than max is cut mid-word.
+func truncateWords(text string, max int) string {
+	var b strings.Builder
+	n := 0
+	for i, word := range strings.Fields(text) {
+		length := utf8.RuneCountInString(word)
+		if i > 0 {
+			length++
+		}
+		if n+length > max {
+			if i == 0 {
+				return string([]rune(word)[:max])
+			}
+			break
+		}
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+		n += length
+	}
+	return b.String()
+}
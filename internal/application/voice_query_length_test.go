@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestTruncateWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		max   int
+		want  string
+	}{
+		{"fits", "daft punk", 20, "daft punk"},
+		{"word boundary", "daft punk around the world", 14, "daft punk"},
+		{"exact boundary", "daft punk around", 16, "daft punk around"},
+		{"long first word", "supercalifragilistic song", 5, "super"},
+		{"multibyte", "café del mar", 8, "café del"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateWords(tt.input, tt.max); got != tt.want {
+				t.Errorf("truncateWords(%q, %d) = %q, want %q", tt.input, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxQueryLength_Truncate(t *testing.T) {
+	svc := newTestService()
+	svc.SetMaxQueryLength(20)
+
+	long := "laser play " + strings.Repeat("la ", 1000)
+	got := parse(t, svc, long)
+	if got != "!play "+strings.TrimSpace(strings.Repeat("la ", 7)) {
+		t.Errorf("parse(long) = %q, want query cut to 20 characters at a word boundary", got)
+	}
+
+	if got := parse(t, svc, "laser play daft punk"); got != "!play daft punk" {
+		t.Errorf("parse = %q, want short query unchanged", got)
+	}
+}
+
+func TestMaxQueryLength_Reject(t *testing.T) {
+	svc := newTestService()
+	svc.SetMaxQueryLength(20)
+	svc.SetQueryOverflow(QueryReject)
+
+	long := "laser play " + strings.Repeat("la ", 1000)
+	if got := parse(t, svc, long); got != "" {
+		t.Errorf("parse(long) = %q, want no command", got)
+	}
+	trace, ok := svc.Explain(context.Background(), long)
+	if ok || trace.Reason != RejectQueryTooLong {
+		t.Errorf("Explain = (%+v, %v), want RejectQueryTooLong", trace.Reason, ok)
+	}
+
+	if got := parse(t, svc, "laser play my "+strings.Repeat("x", 30)+" playlist"); got != "" {
+		t.Errorf("parse(long playlist) = %q, want no command", got)
+	}
+	if got := parse(t, svc, "laser play daft punk"); got != "!play daft punk" {
+		t.Errorf("parse = %q, want short query unchanged", got)
+	}
+}
+
+func TestMaxQueryLength_RejectSkipsLLM(t *testing.T) {
+	llm := &mockLLM{reply: "itsworking"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+	svc.SetMaxQueryLength(10)
+	svc.SetQueryOverflow(QueryReject)
+
+	if got := parse(t, svc, "laser play something far too long"); got != "" {
+		t.Errorf("parse = %q, want no command", got)
+	}
+	if llm.messages != nil {
+		t.Error("LLM was consulted for a rejected query")
+	}
+}
+
+func TestMaxQueryLength_DefaultUnlimited(t *testing.T) {
+	svc := newTestService()
+
+	query := strings.TrimSpace(strings.Repeat("la ", 1000))
+	if got := parse(t, svc, "laser play "+query); got != "!play "+query {
+		t.Errorf("parse truncated a query with no maximum set")
+	}
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"strings"
+	"unicode"
+)
+
+// QuerySanitizer cleans up a spoken play or search query before it is matched
+// or sent downstream.
+type QuerySanitizer func(query string) string
+
+// SetQuerySanitizer replaces the sanitizer applied to play, playlist and search
+// queries (default SanitizeQuery). A nil sanitizer leaves queries as spoken.
+func (s *VoiceService) SetQuerySanitizer(sanitize QuerySanitizer) {
+	s.sanitize = sanitize
+}
+
+// SanitizeQuery removes control characters, invisible formatting characters and
+// emoji from a query and collapses any unicode whitespace to single spaces.
+// Punctuation such as apostrophes, hyphens and slashes is kept, so titles like
+// "Don't Stop Me Now" or "AC/DC" pass through unchanged.
+func SanitizeQuery(query string) string {
+	var b strings.Builder
+	for _, r := range query {
+		switch {
+		case unicode.IsSpace(r):
+			b.WriteByte(' ')
+		case isQueryJunk(r):
+			// dropped
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// isQueryJunk reports whether r never belongs in a query: control and format
+// characters (e.g. zero-width spaces), emoji and other pictographic symbols,
+// and the selectors and modifiers that attach to them.
+func isQueryJunk(r rune) bool {
+	return unicode.IsControl(r) ||
+		unicode.Is(unicode.Cf, r) ||
+		unicode.Is(unicode.So, r) ||
+		unicode.Is(unicode.Variation_Selector, r) ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // emoji skin tone modifiers
+		r == unicode.ReplacementChar
+}
+
+// sanitizeQuery applies the configured sanitizer, if any.
+func (s *VoiceService) sanitizeQuery(query string) string {
+	if s.sanitize == nil {
+		return query
+	}
+	return s.sanitize(query)
+}
+
+// spokenQuery returns the query spoken in cw with trailing politeness removed
+// and the sanitizer applied.
+func (s *VoiceService) spokenQuery(cw commandWords) string {
+	return s.sanitizeQuery(s.trimPoliteness(cw).spokenFrom(0))
+}
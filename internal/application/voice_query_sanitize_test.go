@@ -0,0 +1,72 @@
+package application
+
+import "testing"
+
+func TestSanitizeQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"clean", "Daft Punk", "Daft Punk"},
+		{"control characters", "Daft\x00 Punk\x07", "Daft Punk"},
+		{"zero-width space", "Daft\u200b Punk", "Daft Punk"},
+		{"byte order mark", "\ufeffDaft Punk", "Daft Punk"},
+		{"non-breaking space", "Daft\u00a0Punk", "Daft Punk"},
+		{"em space and tab", "Daft\u2003\tPunk", "Daft Punk"},
+		{"emoji", "lo-fi 🔥 beats", "lo-fi beats"},
+		{"emoji with modifiers", "party 👍🏽 songs ❤️", "party songs"},
+		{"apostrophe", "Don't Stop Me Now", "Don't Stop Me Now"},
+		{"slash and ampersand", "AC/DC & friends", "AC/DC & friends"},
+		{"accents", "Café del Mar", "Café del Mar"},
+		{"only junk", "\x00🔥", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeQuery(tt.input); got != tt.want {
+				t.Errorf("SanitizeQuery(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayCommand_SanitizesQuery(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"control characters", "laser play Daft\x00Punk", "!play DaftPunk"},
+		{"zero-width space", "laser play lo-fi\u200b beats", "!play lo-fi beats"},
+		{"trailing emoji", "laser play lo-fi beats 🔥🔥", "!play lo-fi beats"},
+		{"junk around query", "laser play ~~Daft Punk~~", "!play Daft Punk"},
+		{"title punctuation", "laser play Don't Stop Me Now!", "!play Don't Stop Me Now"},
+		{"search", "laser search jazz\u200b 🎷", "!search jazz"},
+		{"playlist", "laser play my chill\u200b playlist", "!playlist chill"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetQuerySanitizer(t *testing.T) {
+	svc := newTestService()
+
+	svc.SetQuerySanitizer(nil)
+	if got := parse(t, svc, "laser play lo\u200bfi"); got != "!play lo\u200bfi" {
+		t.Errorf("parse without sanitizer = %q, want query unchanged", got)
+	}
+
+	svc.SetQuerySanitizer(func(q string) string { return "custom " + q })
+	if got := parse(t, svc, "laser play jazz"); got != "!play custom jazz" {
+		t.Errorf("parse with custom sanitizer = %q, want %q", got, "!play custom jazz")
+	}
+}
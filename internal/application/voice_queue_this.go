@@ -0,0 +1,35 @@
+package application
+
+// queueThisCommand is the internal name of "queue this" / "add this one".
+const queueThisCommand = "queue-this"
+
+// rememberPreview keeps the query of a search the user just sent, so a later
+// "queue this" can add what was previewed.
+func (s *VoiceService) rememberPreview(key stateKey, trace CommandTrace, cmd VoiceCommand) {
+	if cmd.Name != "search" || trace.Command.Name != "search" || trace.Query == "" {
+		return
+	}
+	s.mu.Lock()
+	s.previews.set(key, trace.Query)
+	s.mu.Unlock()
+}
+
+// resolveQueueThis replaces a "queue this" request with a queue add of the
+// user's last previewed search in the channel. Returns false if the user hasn't
+// previewed anything or queueing has been disabled.
+func (s *VoiceService) resolveQueueThis(key stateKey, cmd VoiceCommand) (VoiceCommand, bool) {
+	if cmd.Name != queueThisCommand {
+		return cmd, true
+	}
+	s.mu.Lock()
+	query, ok := s.previews.get(key)
+	s.mu.Unlock()
+	if !ok || s.commandDisabled("queue") {
+		return VoiceCommand{}, false
+	}
+	queued := s.command("queue", "add", query)
+	queued.WakeToken = cmd.WakeToken
+	queued.FillerPrefix = cmd.FillerPrefix
+	s.markConfirmation(&queued, s.wakePhraseFor(key.channelID))
+	return queued, true
+}
@@ -0,0 +1,61 @@
+package application
+
+import "testing"
+
+func TestQueueThis(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"queue this", "laser queue this"},
+		{"queue that", "laser queue that one"},
+		{"add this one", "laser add this one"},
+		{"add that", "hey laser add that please"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stt := &mockSTT{}
+			svc := NewVoiceService(stt, "laser", nil, nil)
+
+			handle(t, svc, stt, "u1", "laser search Daft Punk")
+			if got := handle(t, svc, stt, "u1", tt.input); got != "!queue add Daft Punk" {
+				t.Errorf("%q = %q, want %q", tt.input, got, "!queue add Daft Punk")
+			}
+		})
+	}
+}
+
+func TestQueueThis_LatestSearch(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	handle(t, svc, stt, "u1", "laser search Daft Punk")
+	handle(t, svc, stt, "u1", "laser search Justice")
+	handle(t, svc, stt, "u1", "laser skip")
+	if got := handle(t, svc, stt, "u1", "laser queue this"); got != "!queue add Justice" {
+		t.Errorf("queue this = %q, want the latest search", got)
+	}
+}
+
+func TestQueueThis_NoPreview(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	if got := handle(t, svc, stt, "u1", "laser queue this"); got != "" {
+		t.Errorf("queue this with no search = %q, want nothing", got)
+	}
+
+	// Another user's and another channel's searches aren't queued.
+	handle(t, svc, stt, "u2", "laser search Daft Punk")
+	handleIn(t, svc, stt, "ch2", "u1", "laser search Justice")
+	if got := handle(t, svc, stt, "u1", "laser add this one"); got != "" {
+		t.Errorf("queue this = %q, want nothing from other users or channels", got)
+	}
+
+	handle(t, svc, stt, "u1", "laser search Daft Punk")
+	svc.ResetUser("u1")
+	if got := handle(t, svc, stt, "u1", "laser queue this"); got != "" {
+		t.Errorf("queue this after reset = %q, want nothing", got)
+	}
+}
@@ -0,0 +1,41 @@
+package application
+
+import (
+	"slices"
+	"strings"
+)
+
+// defaultRandomSynonyms are the whole commands that ask for a random track.
+// "play ... random" is understood anywhere in a play query as well.
+var defaultRandomSynonyms = []string{
+	"surprise me", "random", "random song", "play anything", "play whatever", "play something",
+}
+
+// SetRandomSynonyms replaces the phrases that play a random track ("!pr"), e.g.
+// "surprise me" or "play anything". A synonym must be the whole command, so
+// "play whatever you like" is still played as a query; trailing politeness such
+// as "please" is ignored. Play queries containing "random" keep mapping to "!pr"
+// whatever the synonyms are. Passing nil leaves only those.
+func (s *VoiceService) SetRandomSynonyms(phrases []string) {
+	synonyms := make([]string, 0, len(phrases))
+	for _, p := range phrases {
+		if p = newCommandWords(strings.Fields(p)).text(); p != "" {
+			synonyms = append(synonyms, p)
+		}
+	}
+	s.randomSynonyms = synonyms
+}
+
+// SetRandomCommandOutput replaces the whole "!pr" text of play random commands,
+// prefix included, e.g. with "!play --random" for bots without a dedicated
+// random command. Filters such as "decade:90s" are still appended. The command
+// keeps the name "pr" for aliases, stats and SetCommandEnabled. An empty output
+// restores the default.
+func (s *VoiceService) SetRandomCommandOutput(output string) {
+	s.randomOutput = strings.TrimSpace(output)
+}
+
+// isRandomRequest reports whether cw is one of the random synonyms.
+func (s *VoiceService) isRandomRequest(cw commandWords) bool {
+	return slices.Contains(s.randomSynonyms, s.trimPoliteness(cw).text())
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+)
+
+// randomFillerWords carry no meaning in a play random request, as in "play
+// something random from the 90s".
+var randomFillerWords = wordSet([]string{
+	"random", "randomly", "something", "some", "a", "an", "the", "any",
+	"song", "songs", "track", "tracks", "tune", "tunes", "music", "stuff", "thing",
+	"from", "of", "for", "in", "one", "and", "with", "that", "is",
+})
+
+// decadeWords map spoken decades to their filter value.
+var decadeWords = map[string]string{
+	"sixties": "60s", "seventies": "70s", "eighties": "80s", "nineties": "90s",
+}
+
+// randomGenres and randomMoods are the descriptors classified as genre and
+// mood filters. Punctuation is stripped first, so "lo-fi" arrives as "lofi";
+// "hip hop" is joined into one word.
+var (
+	randomGenres = wordSet([]string{
+		"rock", "pop", "jazz", "metal", "rap", "hiphop", "country", "blues", "classical",
+		"electronic", "edm", "punk", "reggae", "folk", "soul", "funk", "disco", "indie",
+		"lofi", "techno", "house", "grunge", "emo",
+	})
+	randomMoods = wordSet([]string{
+		"chill", "happy", "sad", "upbeat", "relaxing", "energetic", "mellow", "calm",
+		"angry", "romantic", "party", "sleepy", "dark",
+	})
+)
+
+// randomFilters extracts the filters from a play random request: "decade:90s",
+// "genre:rock" and "mood:chill" for recognized descriptors, in the order they
+// were spoken, followed by any other words as a free-text filter, e.g.
+// "a random Taylor Swift song" → ["Taylor Swift"]. Everything after "by" is
+// free text. A plain "play random" has no filters.
+func randomFilters(cw commandWords) []string {
+	var filters, free []string
+	for i := 0; i < len(cw.words); i++ {
+		word := cw.words[i]
+		// What follows "by" names an artist, even "Daft Punk".
+		if word == "by" {
+			free = append(free, cw.spoken[i+1:]...)
+			break
+		}
+		if word == "hip" && i+1 < len(cw.words) && cw.words[i+1] == "hop" {
+			word = "hiphop"
+			i++
+		}
+		decade, isDecade := spokenDecade(word)
+		switch {
+		case randomFillerWords[word]:
+		case randomGenres[word]:
+			filters = append(filters, "genre:"+word)
+		case randomMoods[word]:
+			filters = append(filters, "mood:"+word)
+		case isDecade:
+			filters = append(filters, "decade:"+decade)
+		default:
+			free = append(free, cw.spoken[i])
+		}
+	}
+	if len(free) > 0 {
+		filters = append(filters, strings.Join(free, " "))
+	}
+	return filters
+}
+
+// spokenDecade parses a decade such as "90s", "1990s", "2000s" or "nineties".
+// Twentieth-century decades are shortened to two digits.
+func spokenDecade(word string) (string, bool) {
+	if decade, ok := decadeWords[word]; ok {
+		return decade, true
+	}
+	digits, ok := strings.CutSuffix(word, "s")
+	if !ok || (len(digits) != 2 && len(digits) != 4) || digits[len(digits)-1] != '0' {
+		return "", false
+	}
+	year, err := strconv.Atoi(digits)
+	if err != nil {
+		return "", false
+	}
+	if len(digits) == 4 && year >= 1900 && year < 2000 {
+		return digits[2:] + "s", true
+	}
+	return word, true
+}
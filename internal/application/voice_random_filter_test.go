@@ -0,0 +1,38 @@
+package application
+
+import "testing"
+
+func TestPlayRandomFilters(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", "laser play random", "!pr"},
+		{"something random", "laser play something random", "!pr"},
+		{"decade", "laser play something random from the 90s", "!pr decade:90s"},
+		{"decade with apostrophe", "laser play something random from the '80s", "!pr decade:80s"},
+		{"decade in words", "laser play a random song from the nineties", "!pr decade:90s"},
+		{"full decade", "laser play a random song from the 1970s", "!pr decade:70s"},
+		{"2000s", "laser play random music from the 2000s", "!pr decade:2000s"},
+		{"genre", "laser play a random rock song", "!pr genre:rock"},
+		{"two word genre", "laser play some random hip hop", "!pr genre:hiphop"},
+		{"mood", "laser play something random and chill", "!pr mood:chill"},
+		{"genre and decade", "laser play a random jazz track from the 60s", "!pr genre:jazz decade:60s"},
+		{"free text", "laser play a random Taylor Swift song", "!pr Taylor Swift"},
+		{"free text after by", "laser play something random by Daft Punk", "!pr Daft Punk"},
+		{"genre and free text", "laser play a random pop song by Madonna", "!pr genre:pop Madonna"},
+		{"politeness", "laser play a random rock song please", "!pr genre:rock"},
+		{"other verb", "laser put on something random from the 90s", "!pr decade:90s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestRandomSynonyms_Default(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: "itsworking"}, opts)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser surprise me", "!pr"},
+		{"laser play anything", "!pr"},
+		{"laser play whatever", "!pr"},
+		{"laser play something", "!pr"},
+		{"laser random", "!pr"},
+		{"laser random song", "!pr"},
+		{"laser surprise me please", "!pr"},
+		{"laser Play Anything!", "!pr"},
+		{"laser play random", "!pr"},
+		{"laser play whatever you like", "!play itsworking"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRandomSynonyms_Custom(t *testing.T) {
+	svc := newTestService()
+	svc.SetRandomSynonyms([]string{"Dealer's Choice", "  dj   pick  "})
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser dealer's choice", "!pr"},
+		{"laser dj pick", "!pr"},
+		{"laser surprise me", ""},
+		{"laser play anything", "!play anything"},
+		{"laser play random", "!pr"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRandomSynonyms_CustomInCompound(t *testing.T) {
+	svc := newTestService()
+	svc.SetRandomSynonyms([]string{"dealer's choice"})
+
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"laser stop and dealer's choice", []string{"!stop", "!pr"}},
+		{"laser stop and surprise me", []string{"!stop"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, cmd := range svc.ParseCommands(context.Background(), tt.input) {
+			got = append(got, cmd.Text)
+		}
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("ParseCommands(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRandomCommandOutput(t *testing.T) {
+	svc := newTestService()
+	if err := svc.AddAlias("pr", "bop"); err != nil {
+		t.Fatalf("AddAlias error: %v", err)
+	}
+	svc.SetRandomCommandOutput("!play --random")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser play random", "!play --random"},
+		{"laser surprise me", "!play --random"},
+		{"laser bop", "!play --random"},
+		{"laser play something random from the 90s", "!play --random decade:90s"},
+		{"laser play Daft Punk", "!play Daft Punk"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	svc.SetRandomCommandOutput("")
+	if got := parse(t, svc, "laser play random"); got != "!pr" {
+		t.Errorf("after reset, parse = %q, want %q", got, "!pr")
+	}
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// defaultRankedMatchPrompt is the user prompt sent to the LLM when ranked
+// matching is enabled. It receives MatchPromptData like the match prompt.
+const defaultRankedMatchPrompt = `The user said: {{printf "%q" .Query}}
+
+Available options:
+{{.Options}}
+
+Which options could match what the user asked for? ` +
+	`Reply with ONLY the exact option names, best match first, one per line, at most 5, nothing else. ` +
+	`If nothing matches, reply with the user's original query exactly as given.`
+
+// rankedSystemPrompt is the system message sent alongside the ranked prompt.
+const rankedSystemPrompt = "You are a matching assistant. Given a spoken query and a list of available options, rank the best matches. Reply with only the option names, one per line, no explanation."
+
+var rankedMatchPrompt = template.Must(template.New("ranked").Parse(defaultRankedMatchPrompt))
+
+// SetLLMRankedMatching makes play matching ask the LLM for a ranked list of
+// options instead of a single pick. The top-ranked option is still played; the
+// full list is kept as the trace's Candidates and, for HandleVoice, retrievable
+// with LastCandidates so a handler can offer alternatives. The ranked prompt is
+// fixed; SetMatchPrompt only applies to single-pick matching.
+func (s *VoiceService) SetLLMRankedMatching(enabled bool) {
+	s.rankedMatching = enabled
+}
+
+// LastCandidates returns the ranked options for the user's last play command in
+// the channel, best (the one played) first. It is empty if ranked matching is
+// off or the LLM didn't produce the command.
+func (s *VoiceService) LastCandidates(channelID, userID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates, _ := s.candidates.get(userKey(channelID, userID))
+	return slices.Clone(candidates)
+}
+
+// rememberCandidates keeps the ranked options for a play command the user just
+// sent, replacing those of their previous one.
+func (s *VoiceService) rememberCandidates(key stateKey, trace CommandTrace, cmd VoiceCommand) {
+	if cmd.Name != "play" || trace.Command.Name != "play" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(trace.Candidates) == 0 {
+		s.candidates.take(key)
+		return
+	}
+	s.candidates.set(key, trace.Candidates)
+}
+
+// matchMessages returns the LLM messages for matching query, ranked or single
+// pick depending on the service's setting.
+func (s *VoiceService) matchMessages(query string, options []bot.PlayOption) ([]bot.LLMMessage, error) {
+	if s.rankedMatching {
+		return buildMessages(rankedMatchPrompt, rankedSystemPrompt, query, options)
+	}
+	return s.BuildMatchMessages(query, options)
+}
+
+// rankedReplyOptions returns the available options named on the lines of a
+// ranked LLM reply, in reply order and without duplicates. List markers such as
+// "1." or "-" are ignored, and lines that name no option are skipped.
+func rankedReplyOptions(index *optionIndex, reply string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(reply, "\n") {
+		option, ok := replyOption(index, trimListMarker(line))
+		if !ok || seen[option.Name] {
+			continue
+		}
+		seen[option.Name] = true
+		names = append(names, option.Name)
+	}
+	return names
+}
+
+// trimListMarker strips a leading bullet ("-", "*", "•") or number ("1.",
+// "2)") from a reply line.
+func trimListMarker(line string) string {
+	line = strings.TrimSpace(line)
+	if rest, ok := strings.CutPrefix(line, "-"); ok {
+		return strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(line, "*"); ok {
+		return strings.TrimSpace(rest)
+	}
+	if rest, ok := strings.CutPrefix(line, "•"); ok {
+		return strings.TrimSpace(rest)
+	}
+	digits := strings.IndexFunc(line, func(r rune) bool { return r < '0' || r > '9' })
+	if digits > 0 && (line[digits] == '.' || line[digits] == ')') {
+		return strings.TrimSpace(line[digits+1:])
+	}
+	return line
+}
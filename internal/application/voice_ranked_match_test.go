@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func newRankedService(reply string) (*VoiceService, *mockSTT, *mockLLM) {
+	stt := &mockSTT{}
+	llm := &mockLLM{reply: reply}
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "Daft Punk"}, {Name: "Daft Punk Live"}, {Name: "Justice"}, {Name: "Jazz Classics"},
+	}}
+	svc := NewVoiceService(stt, "laser", llm, opts)
+	svc.SetLLMRankedMatching(true)
+	return svc, stt, llm
+}
+
+func TestRankedMatching(t *testing.T) {
+	svc, stt, llm := newRankedService("1. Daft Punk Live\n2. \"Daft Punk\"\n- Not An Option\n3) Justice\n4. daft punk live")
+
+	if got := handle(t, svc, stt, "u1", "laser play the robots in concert"); got != "!play Daft Punk Live" {
+		t.Errorf("play = %q, want the top-ranked option", got)
+	}
+	want := []string{"Daft Punk Live", "Daft Punk", "Justice"}
+	if got := svc.LastCandidates("ch1", "u1"); !slices.Equal(got, want) {
+		t.Errorf("LastCandidates = %q, want %q", got, want)
+	}
+	if prompt := llm.messages[len(llm.messages)-1].Content; !strings.Contains(prompt, "best match first, one per line") {
+		t.Errorf("prompt %q doesn't ask for a ranked list", prompt)
+	}
+
+	trace, ok := svc.Explain(context.Background(), "laser play the robots in concert")
+	if !ok || !slices.Equal(trace.Candidates, want) {
+		t.Errorf("Explain candidates = %q, %v; want %q", trace.Candidates, ok, want)
+	}
+}
+
+func TestRankedMatching_NoOption(t *testing.T) {
+	svc, stt, llm := newRankedService("Daft Punk\nJustice")
+
+	handle(t, svc, stt, "u1", "laser play the robots")
+	llm.reply = "Something Else\nNothing"
+	if got := handle(t, svc, stt, "u1", "laser play blue monday"); got != "!play blue monday" {
+		t.Errorf("play = %q, want the raw query", got)
+	}
+	if got := svc.LastCandidates("ch1", "u1"); got != nil {
+		t.Errorf("LastCandidates = %q, want the previous play's cleared", got)
+	}
+}
+
+func TestRankedMatching_DisabledByDefault(t *testing.T) {
+	svc, stt, llm := newRankedService("Daft Punk Live\nDaft Punk")
+	svc.SetLLMRankedMatching(false)
+
+	if got := handle(t, svc, stt, "u1", "laser play the robots in concert"); got != "!play the robots in concert" {
+		t.Errorf("play = %q, want the multi-line reply rejected", got)
+	}
+	if got := svc.LastCandidates("ch1", "u1"); got != nil {
+		t.Errorf("LastCandidates = %q, want none", got)
+	}
+	if prompt := llm.messages[len(llm.messages)-1].Content; strings.Contains(prompt, "one per line") {
+		t.Errorf("single-pick prompt asked for a ranked list: %q", prompt)
+	}
+}
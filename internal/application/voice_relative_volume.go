@@ -0,0 +1,105 @@
+package application
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultVolumeStep is how far "louder" or "quieter" moves the volume.
+const defaultVolumeStep = 10
+
+// relativeVolumePhrases map casual phrasings to a volume direction. "turn it
+// off" is deliberately absent: it ends the music rather than lowering it, and
+// is handled with the other turn-off phrases.
+var relativeVolumePhrases = []struct {
+	phrases []string
+	sign    int
+}{
+	{[]string{"turn it up", "turn up the volume", "turn the volume up", "volume up", "louder"}, 1},
+	{[]string{"turn it down", "turn down the volume", "turn the volume down", "volume down", "quieter", "softer"}, -1},
+}
+
+// SetVolumeStep sets how far relative volume commands such as "louder" move
+// the volume (default 10). A step of zero or less restores the default.
+func (s *VoiceService) SetVolumeStep(step int) {
+	if step <= 0 {
+		step = defaultVolumeStep
+	}
+	s.volumeStep = step
+}
+
+// maxVolumeChange caps an explicit relative volume amount, as no change can
+// need more than the whole 0–100 range.
+const maxVolumeChange = 100
+
+// parseRelativeVolume returns the signed volume change for a relative volume
+// phrase, e.g. "turn it up" → "+10". An amount may follow the phrase, as in
+// "volume up by fifteen" → "+15" or "turn it down 5 percent" → "-5"; without
+// one the volume step is used. Amounts are capped at maxVolumeChange. The
+// change is relative, so keeping the resulting level within 0–100 is up to
+// the bot receiving it.
+func (s *VoiceService) parseRelativeVolume(text string) (string, bool) {
+	for _, rv := range relativeVolumePhrases {
+		for _, phrase := range rv.phrases {
+			if !hasPhrasePrefix(text, phrase) {
+				continue
+			}
+			step := s.volumeStep
+			if amount, ok := volumeAmount(text[len(phrase):]); ok {
+				step = amount
+			}
+			return fmt.Sprintf("%+d", rv.sign*step), true
+		}
+	}
+	return "", false
+}
+
+// volumeAmount parses the amount after a relative volume phrase: a number,
+// optionally after "by" and before "percent".
+func volumeAmount(rest string) (int, bool) {
+	words := strings.Fields(normalizeSpokenNumbers(rest))
+	if len(words) > 0 && words[0] == "by" {
+		words = words[1:]
+	}
+	if len(words) == 0 || (len(words) > 1 && words[1] != "percent") {
+		return 0, false
+	}
+	amount, err := strconv.Atoi(words[0])
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+	return min(amount, maxVolumeChange), true
+}
+
+// startsRelativeVolume reports whether text starts with a relative volume phrase.
+func startsRelativeVolume(text string) bool {
+	for _, rv := range relativeVolumePhrases {
+		if hasAnyPhrasePrefix(text, rv.phrases) {
+			return true
+		}
+	}
+	return false
+}
+
+// signedVolume handles a volume command whose level has a sign, as STT writes
+// "volume minus five" as "volume -5". Right after "volume" the sign makes it a
+// relative change, "volume -5" → "-5", capped like other amounts; anywhere
+// else, as in "set the volume to -5", it is no level at all and the change is
+// empty. Returns false if the command has no signed level.
+func signedVolume(cw commandWords) (string, bool) {
+	signedAt := slices.IndexFunc(cw.spoken, func(w string) bool { return w != "" && (w[0] == '-' || w[0] == '+') })
+	if signedAt < 0 || !parsesAsVolume(cw.text()) {
+		return "", false
+	}
+	rest := cw.words[signedAt+1:]
+	if signedAt != 1 || cw.words[0] != "volume" || (len(rest) > 0 && !slices.Equal(rest, []string{"percent"})) {
+		return "", true
+	}
+	amount, err := strconv.Atoi(cw.words[1])
+	if err != nil || amount <= 0 {
+		return "", true
+	}
+	return fmt.Sprintf("%c%d", cw.spoken[1][0], min(amount, maxVolumeChange)), true
+}
@@ -0,0 +1,90 @@
+package application
+
+import "testing"
+
+func TestRelativeVolume(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"turn it up", "laser turn it up", "!volume +10"},
+		{"turn up the volume", "hey laser turn up the volume", "!volume +10"},
+		{"volume up", "laser volume up", "!volume +10"},
+		{"louder", "laser louder", "!volume +10"},
+		{"louder please", "laser louder please", "!volume +10"},
+		{"turn it down", "laser turn it down", "!volume -10"},
+		{"turn the volume down", "laser turn the volume down", "!volume -10"},
+		{"volume down", "laser volume down", "!volume -10"},
+		{"quieter", "laser Quieter!", "!volume -10"},
+		{"softer", "laser softer", "!volume -10"},
+		{"turn it off stops rather than lowering", "laser turn it off", "!stop"},
+		{"turn it", "laser turn it", ""},
+		{"compound", "laser skip and turn it up", "!skip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelativeVolume_Step(t *testing.T) {
+	svc := newTestService()
+	svc.SetVolumeStep(5)
+
+	if got := parse(t, svc, "laser louder"); got != "!volume +5" {
+		t.Errorf("parse = %q, want %q", got, "!volume +5")
+	}
+	if got := parse(t, svc, "laser quieter"); got != "!volume -5" {
+		t.Errorf("parse = %q, want %q", got, "!volume -5")
+	}
+
+	svc.SetVolumeStep(0)
+	if got := parse(t, svc, "laser louder"); got != "!volume +10" {
+		t.Errorf("parse after reset = %q, want %q", got, "!volume +10")
+	}
+}
+
+func TestRelativeVolume_CompoundSplit(t *testing.T) {
+	svc := newTestService()
+
+	cmds := svc.ParseCommands(t.Context(), "laser skip and turn it up")
+	if len(cmds) != 2 || cmds[1].Text != "!volume +10" {
+		t.Errorf("ParseCommands = %+v, want !skip then !volume +10", cmds)
+	}
+}
+
+func TestRelativeVolume_ExplicitAmount(t *testing.T) {
+	svc := newTestService()
+	svc.SetVolumeStep(5)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser volume up by 15", "!volume +15"},
+		{"laser turn it down 5", "!volume -5"},
+		{"laser turn it up by twenty five", "!volume +25"},
+		{"laser louder by 20 percent", "!volume +20"},
+		{"laser quieter by thirty", "!volume -30"},
+		{"laser turn the volume up by a hundred", "!volume +100"},
+		{"laser volume up by 500", "!volume +100"},
+		// Without a usable amount the volume step applies.
+		{"laser volume up", "!volume +5"},
+		{"laser turn it down please", "!volume -5"},
+		{"laser volume up by 0", "!volume +5"},
+		{"laser volume up 5 notches more", "!volume +5"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"errors"
+	"log"
+	"strings"
+)
+
+// renameCommand is the internal name of "change your name to <word>". It is
+// handled by VoiceService itself rather than sent to chat.
+const renameCommand = "rename"
+
+// renamePhrases introduce the new wake word in a rename request.
+var renamePhrases = []string{"change your name to", "call yourself"}
+
+// ErrReservedWakePhrase is returned when a new wake phrase would be mistaken
+// for a command, such as "stop" or "play".
+var ErrReservedWakePhrase = errors.New("wake phrase must not be a command keyword")
+
+// SetAdminUsers sets the user IDs allowed to rename the bot by voice with
+// "laser change your name to jarvis" or "laser call yourself jarvis". It
+// replaces any previous list; nil or empty lets no one rename it.
+func (s *VoiceService) SetAdminUsers(userIDs []string) {
+	s.adminUsers = idSet(userIDs)
+}
+
+// SetWakePhrase changes the wake phrase used in channels without their own. It
+// is safe to call while voice clips are being handled.
+func (s *VoiceService) SetWakePhrase(phrase string) error {
+	if err := ValidateWakePhrase(phrase); err != nil {
+		return err
+	}
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	s.wakePhrase = normalizeWakePhrase(phrase)
+	return nil
+}
+
+// renameWakePhrase replaces the wake phrase the channel uses with phrase.
+func (s *VoiceService) renameWakePhrase(channelID, phrase string) error {
+	if err := ValidateWakePhrase(phrase); err != nil {
+		return err
+	}
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	if _, ok := s.channelWake[channelID]; ok {
+		s.channelWake[channelID] = normalizeWakePhrase(phrase)
+		return nil
+	}
+	s.wakePhrase = normalizeWakePhrase(phrase)
+	return nil
+}
+
+// validateNewWakeWord reports whether a spoken new name can be the wake phrase.
+func (s *VoiceService) validateNewWakeWord(word string) error {
+	if err := ValidateWakePhrase(word); err != nil {
+		return err
+	}
+	if s.startsBuiltinCommand(word) || s.isCommandWord(word) {
+		return ErrReservedWakePhrase
+	}
+	return nil
+}
+
+// isCommandWord reports whether word is the first word of a built-in command
+// keyword, play verb or random synonym, such as "next" ("next song") or "play".
+func (s *VoiceService) isCommandWord(word string) bool {
+	if word == "search" {
+		return true
+	}
+	lists := [][]string{playVerbs, s.randomSynonyms, renamePhrases}
+	for _, kc := range keywordCommands {
+		lists = append(lists, kc.phrases)
+	}
+	for _, phrases := range lists {
+		for _, phrase := range phrases {
+			if first, _, _ := strings.Cut(phrase, " "); first == word {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseRename returns the new name from "change your name to <word>" or "call
+// yourself <word>", trailing politeness removed. The name must be a single
+// word other than a filler; anything else is returned as "" so the request can
+// be refused.
+func (s *VoiceService) parseRename(cw commandWords) (string, bool) {
+	text := cw.text()
+	for _, phrase := range renamePhrases {
+		if !hasPhrasePrefix(text, phrase) {
+			continue
+		}
+		rest := s.trimPoliteness(cw.slice(len(strings.Fields(phrase)), len(cw.words)))
+		if len(rest.words) != 1 || s.commandFillers[rest.words[0]] || s.validateNewWakeWord(rest.words[0]) != nil {
+			return "", true
+		}
+		return rest.words[0], true
+	}
+	return "", false
+}
+
+// renameTrace completes the trace for a rename request, refusing an invalid
+// new name.
+func (s *VoiceService) renameTrace(trace CommandTrace, name string) (CommandTrace, bool) {
+	trace.Branch = BranchKeyword
+	trace.Command = s.command(renameCommand, name)
+	if name == "" {
+		trace.Command = s.command(renameCommand)
+		trace.Reason = RejectInvalidName
+		return trace, false
+	}
+	return trace, true
+}
+
+// renameTarget returns the new wake phrase a rename command carries.
+func renameTarget(cmd VoiceCommand) string {
+	return cmd.Text[strings.LastIndexByte(cmd.Text, ' ')+1:]
+}
+
+// applyRename makes name the wake phrase of the channel an admin asked in:
+// its own phrase if it has one, and otherwise the global phrase.
+func (s *VoiceService) applyRename(channelID, userID, name string) {
+	if err := s.renameWakePhrase(channelID, name); err != nil {
+		s.logger.Warn("rename failed", "channel", channelID, "user", userID, "name", name, "error", err)
+		return
+	}
+	log.Printf("wake phrase changed to %q by user %s", name, userID)
+	s.logger.Info("wake phrase changed", "channel", channelID, "user", userID, "name", name)
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"change your name", "laser change your name to Jarvis"},
+		{"call yourself", "laser call yourself jarvis please"},
+		{"with lead-in", "hey laser could you call yourself Jarvis."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stt := &mockSTT{text: tt.input}
+			svc := NewVoiceService(stt, "laser", nil, nil)
+			svc.SetAdminUsers([]string{"admin"})
+			svc.SetMaxInterveningWords(2)
+
+			result, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "admin", []byte("fake-audio"))
+			if err != nil {
+				t.Fatalf("HandleVoiceDetailed error: %v", err)
+			}
+			if result.Outcome != OutcomeWakePhraseChanged || result.Command != "" {
+				t.Errorf("rename result = %+v, want outcome %q and no command", result, OutcomeWakePhraseChanged)
+			}
+			if want := "Call me jarvis from now on"; result.Confirmation != want {
+				t.Errorf("Confirmation = %q, want %q", result.Confirmation, want)
+			}
+			if got := svc.Config().WakePhrase; got != "jarvis" {
+				t.Errorf("wake phrase = %q, want %q", got, "jarvis")
+			}
+
+			if got := handle(t, svc, stt, "u1", "jarvis stop"); got != "!stop" {
+				t.Errorf("new wake phrase = %q, want %q", got, "!stop")
+			}
+			if got := handle(t, svc, stt, "u1", "laser stop"); got != "" {
+				t.Errorf("old wake phrase = %q, want no command", got)
+			}
+		})
+	}
+}
+
+func TestRename_NotAdmin(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	if got := handle(t, svc, stt, "u1", "laser call yourself jarvis"); got != "" {
+		t.Errorf("rename without admins = %q, want nothing", got)
+	}
+	svc.SetAdminUsers([]string{"admin"})
+	if got := handle(t, svc, stt, "u1", "laser call yourself jarvis"); got != "" {
+		t.Errorf("rename by non-admin = %q, want nothing", got)
+	}
+	if got := svc.Config().WakePhrase; got != "laser" {
+		t.Errorf("wake phrase = %q, want it unchanged", got)
+	}
+
+	stt.text = "laser call yourself jarvis"
+	result, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil || result.Outcome != OutcomeNoCommandAfterWake || result.Reason != RejectNotAdmin {
+		t.Errorf("rename by non-admin = %+v, %v; want refused with %q", result, err, RejectNotAdmin)
+	}
+	if stats := svc.Stats(); stats.Matched != 0 || stats.NoMatch != 3 || stats.Commands[renameCommand] != 0 {
+		t.Errorf("Stats = %+v, want the refused renames counted as no match", stats)
+	}
+}
+
+func TestRename_ChannelWakePhrase(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetAdminUsers([]string{"admin"})
+	svc.SetChannelWakePhrase("ch1", "buddy")
+
+	handle(t, svc, stt, "admin", "buddy call yourself jarvis")
+	if got := handle(t, svc, stt, "admin", "jarvis stop"); got != "!stop" {
+		t.Errorf("renamed channel phrase = %q, want %q", got, "!stop")
+	}
+	if got := svc.Config().WakePhrase; got != "laser" {
+		t.Errorf("global wake phrase = %q, want it unchanged", got)
+	}
+}
+
+func TestRename_InvalidNames(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetAdminUsers([]string{"admin"})
+
+	for _, input := range []string{
+		"laser change your name to",
+		"laser call yourself please",
+		"laser call yourself stop",
+		"laser change your name to play",
+		"laser call yourself random",
+		"laser call yourself mister jarvis",
+	} {
+		trace, ok := svc.Explain(context.Background(), input)
+		if ok || trace.Reason != RejectInvalidName {
+			t.Errorf("Explain(%q) = %q, %v, reason %q; want %q", input, trace.Command.Text, ok, trace.Reason, RejectInvalidName)
+		}
+		if got := handle(t, svc, stt, "admin", input); got != "" {
+			t.Errorf("%q = %q, want nothing", input, got)
+		}
+	}
+	if got := svc.Config().WakePhrase; got != "laser" {
+		t.Errorf("wake phrase = %q, want it unchanged", got)
+	}
+}
+
+func TestSetWakePhrase(t *testing.T) {
+	svc := newTestService()
+	if err := svc.SetWakePhrase(" "); err != ErrEmptyWakePhrase {
+		t.Errorf("SetWakePhrase(blank) error = %v, want %v", err, ErrEmptyWakePhrase)
+	}
+	if err := svc.SetWakePhrase(" Jarvis "); err != nil {
+		t.Fatalf("SetWakePhrase error: %v", err)
+	}
+	if got := parse(t, svc, "jarvis skip"); got != "!skip" {
+		t.Errorf("parse = %q, want %q", got, "!skip")
+	}
+}
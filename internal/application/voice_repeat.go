@@ -0,0 +1,27 @@
+package application
+
+// repeatCommand is the internal name of "again" / "do that again".
+const repeatCommand = "again"
+
+// resolveRepeat replaces a repeat request with the last command the user sent
+// in the channel, taken from the recent command history. The repeated command
+// is recorded under its own name, so repeating twice re-sends the original
+// rather than looping. Commands that need confirmation need it again. Returns
+// false if there is nothing to repeat or the command has since been disabled.
+func (s *VoiceService) resolveRepeat(key stateKey, cmd VoiceCommand) (VoiceCommand, bool) {
+	if cmd.Name != repeatCommand {
+		return cmd, true
+	}
+	last, ok := s.lastCommand(key.channelID, key.userID)
+	if !ok || s.commandDisabled(last.Name) {
+		return VoiceCommand{}, false
+	}
+	repeated := VoiceCommand{
+		Name:         last.Name,
+		Text:         last.Text,
+		WakeToken:    cmd.WakeToken,
+		FillerPrefix: cmd.FillerPrefix,
+	}
+	s.markConfirmation(&repeated, s.wakePhraseFor(key.channelID))
+	return repeated, true
+}
@@ -0,0 +1,80 @@
+package application
+
+import "testing"
+
+func TestRepeat(t *testing.T) {
+	tests := []struct {
+		name   string
+		repeat string
+	}{
+		{"again", "laser again"},
+		{"do that again", "hey laser do that again"},
+		{"repeat that", "laser repeat that"},
+		{"play that again", "laser play that again"},
+		{"one more time", "laser one more time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stt := &mockSTT{}
+			svc := NewVoiceService(stt, "laser", nil, nil)
+
+			handle(t, svc, stt, "u1", "laser play Daft Punk")
+			if got := handle(t, svc, stt, "u1", tt.repeat); got != "!play Daft Punk" {
+				t.Errorf("repeat = %q, want %q", got, "!play Daft Punk")
+			}
+		})
+	}
+}
+
+func TestRepeat_NoHistory(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	if got := handle(t, svc, stt, "u1", "laser again"); got != "" {
+		t.Errorf("repeat with no history = %q, want nothing", got)
+	}
+
+	// Another user's and another channel's commands aren't repeated.
+	handle(t, svc, stt, "u2", "laser skip")
+	handleIn(t, svc, stt, "ch2", "u1", "laser pause")
+	if got := handle(t, svc, stt, "u1", "laser again"); got != "" {
+		t.Errorf("repeat = %q, want nothing from other users or channels", got)
+	}
+}
+
+func TestRepeat_NoLoop(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	handle(t, svc, stt, "u1", "laser skip")
+	for i := range 3 {
+		if got := handle(t, svc, stt, "u1", "laser again"); got != "!skip" {
+			t.Fatalf("repeat %d = %q, want %q", i+1, got, "!skip")
+		}
+	}
+
+	for _, cmd := range svc.RecentCommands("ch1") {
+		if cmd.Name != "skip" || cmd.Text != "!skip" {
+			t.Errorf("history entry %+v, want only !skip", cmd)
+		}
+	}
+	if got := svc.Stats().Commands[repeatCommand]; got != 3 {
+		t.Errorf("repeat requests counted = %d, want 3", got)
+	}
+}
+
+func TestRepeat_NeedsConfirmationAgain(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newConfirmService(stt)
+
+	handle(t, svc, stt, "u1", "laser clear the queue")
+	handle(t, svc, stt, "u1", "laser confirm")
+
+	if got := handle(t, svc, stt, "u1", "laser again"); got != "" {
+		t.Errorf("repeat of a confirmed command = %q, want it held", got)
+	}
+	if got := handle(t, svc, stt, "u1", "laser yes"); got != "!clear" {
+		t.Errorf("confirm repeat = %q, want %q", got, "!clear")
+	}
+}
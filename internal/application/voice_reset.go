@@ -0,0 +1,33 @@
+package application
+
+import "context"
+
+// ResetUser discards the user's per-user state in every channel, such as a
+// pending confirmation or a buffered wake phrase, e.g. when they leave voice.
+// Configuration such as channel wake phrases is kept.
+func (s *VoiceService) ResetUser(userID string) {
+	s.resetState(func(key stateKey) bool { return key.userID == userID })
+}
+
+// ResetChannel discards the per-user state of everyone in the channel and its
+// command history. Configuration such as the channel's wake phrase is kept.
+func (s *VoiceService) ResetChannel(channelID string) {
+	s.resetState(func(key stateKey) bool { return key.channelID == channelID })
+
+	s.mu.Lock()
+	delete(s.history, channelID)
+	s.mu.Unlock()
+	s.saveHistory(context.Background(), channelID)
+}
+
+// resetState deletes the per-user state for keys matching the predicate.
+func (s *VoiceService) resetState(match func(stateKey) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending.deleteFunc(match)
+	s.wakeBuffer.deleteFunc(match)
+	s.previews.deleteFunc(match)
+	s.candidates.deleteFunc(match)
+	s.results.deleteFunc(match)
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// handleIn runs a transcription through HandleVoice for the user in the channel.
+func handleIn(t *testing.T, svc *VoiceService, stt *mockSTT, channelID, userID, text string) string {
+	t.Helper()
+	stt.text = text
+	got, err := svc.HandleVoice(context.Background(), channelID, userID, []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice(%q) error: %v", text, err)
+	}
+	return got
+}
+
+func newResetService(stt *mockSTT) *VoiceService {
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetClock(newFakeClock())
+	svc.SetConfirmCommands([]string{"clear"})
+	svc.SetWakeBufferWindow(3 * time.Second)
+	return svc
+}
+
+func TestResetUser(t *testing.T) {
+	stt := &mockSTT{}
+	svc := newResetService(stt)
+
+	handleIn(t, svc, stt, "ch1", "u1", "laser clear the queue")
+	handleIn(t, svc, stt, "ch2", "u1", "laser")
+	handleIn(t, svc, stt, "ch1", "u2", "laser clear the queue")
+
+	svc.ResetUser("u1")
+
+	if got := handleIn(t, svc, stt, "ch1", "u1", "laser confirm"); got != "" {
+		t.Errorf("confirm after ResetUser = %q, want nothing pending", got)
+	}
+	if got := handleIn(t, svc, stt, "ch2", "u1", "stop"); got != "" {
+		t.Errorf("segment after ResetUser = %q, want no buffered wake phrase", got)
+	}
+	if got := handleIn(t, svc, stt, "ch1", "u2", "laser confirm"); got != "!clear" {
+		t.Errorf("other user's confirm = %q, want %q", got, "!clear")
+	}
+}
+
+func TestResetChannel(t *testing.T) {
+	stt := &mockSTT{}
+	svc := newResetService(stt)
+	svc.SetChannelWakePhrase("ch1", "buddy")
+
+	handleIn(t, svc, stt, "ch1", "u1", "buddy clear the queue")
+	handleIn(t, svc, stt, "ch1", "u2", "buddy")
+	handleIn(t, svc, stt, "ch2", "u1", "laser clear the queue")
+
+	svc.ResetChannel("ch1")
+
+	if got := handleIn(t, svc, stt, "ch1", "u1", "buddy confirm"); got != "" {
+		t.Errorf("confirm after ResetChannel = %q, want nothing pending", got)
+	}
+	if got := handleIn(t, svc, stt, "ch1", "u2", "stop"); got != "" {
+		t.Errorf("segment after ResetChannel = %q, want no buffered wake phrase", got)
+	}
+	if got := handleIn(t, svc, stt, "ch1", "u2", "buddy stop"); got != "!stop" {
+		t.Errorf("channel wake phrase after ResetChannel = %q, want %q", got, "!stop")
+	}
+	if got := handleIn(t, svc, stt, "ch2", "u1", "laser confirm"); got != "!clear" {
+		t.Errorf("other channel's confirm = %q, want %q", got, "!clear")
+	}
+}
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"slices"
+	"strconv"
+)
+
+// resultCommand is the internal name of "play the <ordinal> result". Its
+// "number" argument is the 1-based entry of the user's last results.
+const resultCommand = "play-result"
+
+// ordinals maps spoken ordinals, in word and STT's digit form, to a zero-based
+// index.
+var ordinals = map[string]int{
+	"first": 0, "second": 1, "third": 2, "fourth": 3, "fifth": 4,
+	"sixth": 5, "seventh": 6, "eighth": 7, "ninth": 8, "tenth": 9,
+	"1st": 0, "2nd": 1, "3rd": 2, "4th": 3, "5th": 4,
+	"6th": 5, "7th": 6, "8th": 7, "9th": 8, "10th": 9,
+}
+
+// resultOrdinal returns the index named by a play query like "the third result"
+// or "second option".
+func resultOrdinal(words []string) (int, bool) {
+	if len(words) > 0 && words[0] == "the" {
+		words = words[1:]
+	}
+	if len(words) != 2 || (words[1] != "result" && words[1] != "option") {
+		return 0, false
+	}
+	n, ok := ordinals[words[0]]
+	return n, ok
+}
+
+// resultTrace turns "play the <ordinal> result" into a request for that entry
+// of the user's last results, resolved by resolveResult once the user is known.
+func (s *VoiceService) resultTrace(trace CommandTrace, n int, shuffle bool) (CommandTrace, bool) {
+	trace.Branch = BranchKeyword
+	trace.Command = s.commandWith(resultCommand, map[string]string{
+		"number":  strconv.Itoa(n + 1),
+		"shuffle": strconv.FormatBool(shuffle),
+	})
+	return trace, true
+}
+
+// SetLastResults records the results a handler showed the user in the
+// channel, in the order shown, e.g. the music bot's reply to their search, so
+// "laser play the third result" plays the third of them. It replaces the
+// user's previous results; an empty list clears them.
+func (s *VoiceService) SetLastResults(channelID, userID string, results []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := userKey(channelID, userID)
+	if len(results) == 0 {
+		s.results.take(key)
+		return
+	}
+	s.results.set(key, slices.Clone(results))
+}
+
+// rememberResults updates the user's last results for a command they just
+// sent: a ranked play presents its candidates, and a search makes the earlier
+// results stale until the handler records the new ones.
+func (s *VoiceService) rememberResults(key stateKey, trace CommandTrace, cmd VoiceCommand) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case cmd.Name == "search":
+		s.results.take(key)
+	case cmd.Name == "play" && trace.Command.Name == "play" && len(trace.Candidates) > 1:
+		s.results.set(key, trace.Candidates)
+	}
+}
+
+// resolveResult replaces a "play the <ordinal> result" request with a play of
+// that entry of the user's last results in the channel. It returns the reason
+// the request was refused if the user has no results or fewer than it names.
+func (s *VoiceService) resolveResult(key stateKey, cmd VoiceCommand) (VoiceCommand, RejectReason) {
+	if cmd.Name != resultCommand {
+		return cmd, ""
+	}
+	if s.commandDisabled("play") {
+		return VoiceCommand{}, RejectDisabled
+	}
+	s.mu.Lock()
+	results, ok := s.results.get(key)
+	s.mu.Unlock()
+	if !ok {
+		return VoiceCommand{}, RejectNoResults
+	}
+	n, _ := strconv.Atoi(cmd.Args["number"])
+	if n < 1 || n > len(results) {
+		return VoiceCommand{}, RejectNoSuchResult
+	}
+	shuffle, _ := strconv.ParseBool(cmd.Args["shuffle"])
+	played := s.playCommand("play", results[n-1], shuffle)
+	played.WakeToken = cmd.WakeToken
+	played.FillerPrefix = cmd.FillerPrefix
+	s.markConfirmation(&played, s.wakePhraseFor(key.channelID))
+	return played, ""
+}
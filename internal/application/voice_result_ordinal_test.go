@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPlayResultOrdinal(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetLastResults("ch1", "u1", []string{"itsworking", "miragewish", "Daft Punk"})
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser play the first result", "!play itsworking"},
+		{"laser play the third result", "!play Daft Punk"},
+		{"laser play second option", "!play miragewish"},
+		{"laser play the 3rd result please", "!play Daft Punk"},
+		{"laser shuffle play the first result", "!play itsworking --shuffle"},
+		{"laser play the fourth result", ""},
+		{"laser play the tenth result", ""},
+	}
+	for _, tt := range tests {
+		if got := handle(t, svc, stt, "u1", tt.input); got != tt.want {
+			t.Errorf("handle(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPlayResultOrdinal_Refused(t *testing.T) {
+	stt := &mockSTT{text: "laser play the second result"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetLastResults("ch1", "u1", []string{"itsworking"})
+
+	for _, tt := range []struct {
+		user string
+		want RejectReason
+	}{
+		{"u1", RejectNoSuchResult},
+		{"u2", RejectNoResults},
+	} {
+		result, err := svc.HandleVoiceDetailed(context.Background(), "ch1", tt.user, []byte("fake-audio"))
+		if err != nil {
+			t.Fatalf("HandleVoiceDetailed: %v", err)
+		}
+		if result.Command != "" || result.Outcome != OutcomeNoCommandAfterWake || result.Reason != tt.want {
+			t.Errorf("user %s: result = %+v, want refused with %q", tt.user, result, tt.want)
+		}
+	}
+}
+
+func TestPlayResultOrdinal_PerUser(t *testing.T) {
+	svc, stt, _ := newRankedService("Daft Punk Live\nDaft Punk\nJustice")
+
+	if got := handle(t, svc, stt, "u1", "laser play the robots in concert"); got != "!play Daft Punk Live" {
+		t.Fatalf("play = %q, want the top-ranked option", got)
+	}
+	// The ranked options shown to u1 are theirs alone, whatever was fetched.
+	if got := handle(t, svc, stt, "u1", "laser play the third result"); got != "!play Justice" {
+		t.Errorf("u1 third result = %q, want their third candidate", got)
+	}
+	if got := handle(t, svc, stt, "u2", "laser play the first result"); got != "" {
+		t.Errorf("u2 first result = %q, want nothing without results", got)
+	}
+
+	// A new search makes the earlier results stale.
+	handle(t, svc, stt, "u1", "laser search daft punk")
+	if got := handle(t, svc, stt, "u1", "laser play the first result"); got != "" {
+		t.Errorf("first result after a search = %q, want nothing until results are recorded", got)
+	}
+	svc.SetLastResults("ch1", "u1", []string{"Around the World"})
+	if got := handle(t, svc, stt, "u1", "laser play the first result"); got != "!play Around the World" {
+		t.Errorf("first result = %q, want the recorded result", got)
+	}
+
+	svc.ResetUser("u1")
+	if got := handle(t, svc, stt, "u1", "laser play the first result"); got != "" {
+		t.Errorf("first result after reset = %q, want nothing", got)
+	}
+}
+
+func TestPlayResultOrdinal_NotAResult(t *testing.T) {
+	svc := newTestService()
+
+	if got := parse(t, svc, "laser play the first result of the day"); got != "!play the first result of the day" {
+		t.Errorf("parse = %q, want a plain play query", got)
+	}
+}
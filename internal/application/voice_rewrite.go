@@ -0,0 +1,33 @@
+package application
+
+import "log"
+
+// CommandRewriter changes a parsed command before HandleVoice returns it, e.g.
+// to add a guild-specific flag or turn "!pr" into "!play random". Returning a
+// command with empty Text suppresses it.
+type CommandRewriter func(cmd VoiceCommand) VoiceCommand
+
+// SetCommandRewriter sets a hook applied to every command HandleVoice is about
+// to return, after confirmation and repeats are resolved. Command history keeps
+// the command as parsed, so "again" is rewritten afresh. A nil rewriter (the
+// default) leaves commands as parsed.
+func (s *VoiceService) SetCommandRewriter(rewrite CommandRewriter) {
+	s.rewrite = rewrite
+}
+
+// rewriteCommand applies the command rewriter, if any, and reports whether a
+// command is left to send.
+func (s *VoiceService) rewriteCommand(userID string, cmd VoiceCommand) (VoiceCommand, bool) {
+	if s.rewrite == nil {
+		return cmd, true
+	}
+	rewritten := s.rewrite(cmd)
+	if rewritten.Text == "" {
+		log.Printf("voice command from user %s suppressed by rewriter: %s", userID, cmd.Text)
+		return rewritten, false
+	}
+	if rewritten.Text != cmd.Text {
+		log.Printf("voice command from user %s rewritten: %s -> %s", userID, cmd.Text, rewritten.Text)
+	}
+	return rewritten, true
+}
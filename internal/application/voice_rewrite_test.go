@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestCommandRewriter_AppendsSuffix(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	var seen []string
+	svc.SetCommandRewriter(func(cmd VoiceCommand) VoiceCommand {
+		seen = append(seen, cmd.Name)
+		cmd.Text += " --guild=home"
+		return cmd
+	})
+
+	if got := handle(t, svc, stt, "u1", "laser stop"); got != "!stop --guild=home" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!stop --guild=home")
+	}
+	if got := handle(t, svc, stt, "u1", "laser play some jazz"); got != "!play some jazz --guild=home" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!play some jazz --guild=home")
+	}
+	// History keeps the parsed command, so a repeat is only rewritten once.
+	if got := handle(t, svc, stt, "u1", "laser again"); got != "!play some jazz --guild=home" {
+		t.Errorf("repeat = %q, want %q", got, "!play some jazz --guild=home")
+	}
+	if want := []string{"stop", "play", "play"}; !slices.Equal(seen, want) {
+		t.Errorf("rewriter saw %v, want %v", seen, want)
+	}
+}
+
+func TestCommandRewriter_MapsCommand(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetCommandRewriter(func(cmd VoiceCommand) VoiceCommand {
+		if cmd.Name == "pr" {
+			cmd.Text = "!play random"
+		}
+		return cmd
+	})
+
+	if got := handle(t, svc, stt, "u1", "laser play random"); got != "!play random" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!play random")
+	}
+	if got := handle(t, svc, stt, "u1", "laser skip"); got != "!skip" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!skip")
+	}
+}
+
+func TestCommandRewriter_Suppresses(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetCommandRewriter(func(cmd VoiceCommand) VoiceCommand {
+		if cmd.Name == "leave" {
+			return VoiceCommand{}
+		}
+		return cmd
+	})
+
+	stt.text = "laser leave"
+	result, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoiceDetailed error: %v", err)
+	}
+	if result.Command != "" || result.Outcome != OutcomeSuppressed {
+		t.Errorf("result = %+v, want no command and outcome %q", result, OutcomeSuppressed)
+	}
+	if got := handle(t, svc, stt, "u1", "laser stop"); got != "!stop" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!stop")
+	}
+}
+
+func TestCommandRewriter_Nil(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetCommandRewriter(nil)
+
+	if got := handle(t, svc, stt, "u1", "laser stop"); got != "!stop" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!stop")
+	}
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+)
+
+// seekCommand jumps to a position in the current track, given in seconds.
+const seekCommand = "seek"
+
+// seekPhrases start a seek command, e.g. "skip to 1:30" or "seek to two minutes".
+var seekPhrases = []string{"seek to", "skip to", "jump to", "seek"}
+
+// parseSeek returns the position in seconds named by a seek command. The
+// position is a clock time ("1:30", "1:02:00"), a spoken duration ("two minutes
+// thirty", "90 seconds") or a bare number of seconds. A malformed clock time
+// gives a position of -1. Returns false if the words are no seek command, so
+// "skip to the next song" is still a skip.
+func parseSeek(cw commandWords) (int, bool) {
+	text := cw.text()
+	for _, phrase := range seekPhrases {
+		if !hasPhrasePrefix(text, phrase) {
+			continue
+		}
+		n := len(strings.Fields(phrase))
+		// The colon is stripped from the words, so "1:30" is read from spoken.
+		if len(cw.words) == n+1 && strings.Contains(cw.spoken[n], ":") {
+			if seconds, ok := clockPosition(cw.spoken[n]); ok {
+				return seconds, true
+			}
+			return -1, true
+		}
+		return spokenPosition(strings.Fields(normalizeSpokenNumbers(cw.slice(n, len(cw.words)).text())))
+	}
+	return 0, false
+}
+
+// clockPosition parses "m:ss" or "h:mm:ss".
+func clockPosition(s string) (int, bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+	seconds := 0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || (i > 0 && (len(part) != 2 || n > 59)) {
+			return 0, false
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, true
+}
+
+// positionUnits maps the time units of a spoken seek position to seconds.
+var positionUnits = map[string]int{
+	"hour": 3600, "hours": 3600, "minute": 60, "minutes": 60,
+	"second": 1, "seconds": 1,
+}
+
+// spokenPosition parses numbers followed by "hours", "minutes" or "seconds".
+// A bare number counts as seconds, or as the seconds after a minute count, as
+// in "one minute thirty".
+func spokenPosition(words []string) (int, bool) {
+	if len(words) == 0 {
+		return 0, false
+	}
+	seconds := 0
+	for i := 0; i < len(words); i++ {
+		if words[i] == "and" && i > 0 {
+			continue
+		}
+		n, err := strconv.Atoi(words[i])
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		unit := 1
+		if i+1 < len(words) {
+			u, ok := positionUnits[words[i+1]]
+			if !ok {
+				return 0, false
+			}
+			unit = u
+			i++
+		}
+		seconds += n * unit
+	}
+	return seconds, true
+}
+
+// seekTrace resolves a seek to position seconds, which needs a playing track.
+func (s *VoiceService) seekTrace(trace CommandTrace, position int) (CommandTrace, bool) {
+	trace.Branch = BranchKeyword
+	if position < 0 {
+		trace.Command = s.command(seekCommand)
+		trace.Reason = RejectInvalidPosition
+		return trace, false
+	}
+	trace.Command = s.commandWith(seekCommand, map[string]string{"position": strconv.Itoa(position)})
+	if trace.Reason = s.playbackRejection(needPlaying); trace.Reason != "" {
+		return trace, false
+	}
+	return trace, true
+}
@@ -0,0 +1,42 @@
+package application
+
+import "testing"
+
+func TestSeek(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"clock", "laser skip to 1:30", "!seek 90"},
+		{"hours clock", "laser seek to 1:02:03", "!seek 3723"},
+		{"minutes", "laser seek to two minutes", "!seek 120"},
+		{"minutes and seconds", "laser jump to one minute and thirty seconds", "!seek 90"},
+		{"minute and bare seconds", "laser skip to one minute thirty", "!seek 90"},
+		{"seconds", "laser seek 45 seconds", "!seek 45"},
+		{"bare seconds", "laser jump to ninety", "!seek 90"},
+		{"bad clock", "laser skip to 1:75", ""},
+		{"skip to the next song is a skip", "laser skip to the next song", "!skip"},
+		{"bare seek", "laser seek", ""},
+		{"compound", "laser pause and skip to 2:00", "!pause"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeek_NeedsPlayback(t *testing.T) {
+	svc := newTestService()
+	svc.SetPlaybackState(&mockPlayback{})
+
+	if got := parse(t, svc, "laser skip to 1:30"); got != "" {
+		t.Errorf("seek while stopped = %q, want no command", got)
+	}
+}
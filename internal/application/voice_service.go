@@ -2,171 +2,1578 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
 )
 
 // VoiceCommand represents a parsed voice command result.
 type VoiceCommand struct {
+	// Name identifies the command (e.g. "stop", "pr", "play").
+	Name string
 	// Text is the message to send to the output text channel.
 	Text string
+	// Args holds the command's structured arguments by name, e.g. "level" for
+	// volume or "position" for seek, so handlers need not re-parse Text. It is
+	// nil for commands without any.
+	Args map[string]string
+	// WakeToken is the wake phrase variant that was spoken (e.g. "lazer").
+	WakeToken string
+	// FillerPrefix holds any filler words spoken before the wake phrase (e.g. "hey").
+	FillerPrefix string
+	// RequiresConfirmation is set for commands that are held until the user
+	// confirms them by saying FollowUp.
+	RequiresConfirmation bool
+	// FollowUp is the phrase that confirms a command (e.g. "laser confirm").
+	FollowUp string
+}
+
+// ParseResult is the outcome of parsing one transcription in ParseBatch.
+type ParseResult struct {
+	// Input is the transcription as given.
+	Input string
+	// Command is the command text, or empty if nothing matched.
+	Command string
+	// Branch is the parsing branch that produced Command, or empty.
+	Branch MatchBranch
+}
+
+// VoiceResult is the outcome of handling one voice clip in HandleVoiceDetailed.
+type VoiceResult struct {
+	// Transcription is the trimmed STT output, or empty if nothing was heard.
+	Transcription string
+	// Command is the command text to send to chat, or empty if none.
+	Command string
+	// Outcome says whether a command was produced and, if not, why. It is
+	// empty when an error is returned.
+	Outcome VoiceOutcome
+	// Confirmation is a short phrase describing the command, e.g. "Stopping
+	// playback", for speaking back to the user. It is empty without a command
+	// or if the command has no confirmation template.
+	Confirmation string
+	// Reason explains why a recognized command was refused, e.g. "skip" while
+	// nothing is playing, so the caller can reply helpfully. It is empty unless
+	// the outcome is OutcomeNoCommandAfterWake.
+	Reason RejectReason
+}
+
+// VoiceOutcome classifies the result of handling a voice clip.
+type VoiceOutcome string
+
+const (
+	// OutcomeIgnoredUser means the user is denied (or not allowed) and the clip
+	// was dropped without being transcribed.
+	OutcomeIgnoredUser VoiceOutcome = "ignored user"
+	// OutcomeNoSpeech means nothing was transcribed, or the clip was too short
+	// to transcribe.
+	OutcomeNoSpeech VoiceOutcome = "no speech"
+	// OutcomeNoWakePhrase means speech was heard but didn't contain the wake phrase.
+	OutcomeNoWakePhrase VoiceOutcome = "no wake phrase"
+	// OutcomeNoCommandAfterWake means the wake phrase was heard but what followed
+	// wasn't a command that could be sent. When the wake phrase isn't required,
+	// any transcription without a command gets this outcome.
+	OutcomeNoCommandAfterWake VoiceOutcome = "no command after wake phrase"
+	// OutcomeAwaitingConfirmation means the command is held until the user confirms it.
+	OutcomeAwaitingConfirmation VoiceOutcome = "awaiting confirmation"
+	// OutcomeSuppressed means a command was parsed but the command rewriter
+	// suppressed it.
+	OutcomeSuppressed VoiceOutcome = "suppressed"
+	// OutcomeWakePhraseChanged means an admin renamed the bot; the new wake
+	// phrase applies to later clips. No command is sent to chat.
+	OutcomeWakePhraseChanged VoiceOutcome = "wake phrase changed"
+	// OutcomeMatched means a command was produced.
+	OutcomeMatched VoiceOutcome = "matched"
+)
+
+// VoiceStats is a snapshot of voice command counters.
+type VoiceStats struct {
+	// Transcriptions counts non-empty transcriptions that were parsed.
+	Transcriptions int64
+	// Matched counts transcriptions that produced a command.
+	Matched int64
+	// NoMatch counts transcriptions that produced no command.
+	NoMatch int64
+	// MatchErrors counts play commands whose option matching failed, e.g.
+	// because GetOptions errored, and fell back to the raw query.
+	MatchErrors int64
+	// Commands counts matched commands by name.
+	Commands map[string]int64
+}
+
+// MatchBranch identifies which parsing branch produced a voice command.
+type MatchBranch string
+
+const (
+	// BranchKeyword means a fixed command keyword matched (e.g. "stop", "play random").
+	BranchKeyword MatchBranch = "keyword"
+	// BranchLLM means the LLM matched the play query against the available options.
+	BranchLLM MatchBranch = "llm"
+	// BranchLocal means the local fuzzy matcher picked the closest option.
+	BranchLocal MatchBranch = "local"
+	// BranchSubstring means the play query contained an option's name, which
+	// was picked without asking the LLM.
+	BranchSubstring MatchBranch = "substring"
+	// BranchPhonetic means an option that sounds like the play query was
+	// picked without asking the LLM.
+	BranchPhonetic MatchBranch = "phonetic"
+	// BranchPassthrough means the raw play query was passed through unchanged.
+	BranchPassthrough MatchBranch = "passthrough"
+)
+
+// CommandTrace describes how a transcription was resolved into a command.
+type CommandTrace struct {
+	// Transcription is the input as given.
+	Transcription string
+	// WakeFound reports whether the wake phrase was found, or wasn't required.
+	WakeFound bool
+	// Remainder is the normalized text following the wake phrase.
+	Remainder string
+	// Homophone is the first word of the command if it was read as a command
+	// keyword, e.g. "paws" for "pause". Remainder has the keyword in its place.
+	Homophone string
+	// Query is the spoken play query, if the play branch was taken.
+	Query string
+	// Branch is the parsing branch that produced Command.
+	Branch MatchBranch
+	// Command is the resulting command.
+	Command VoiceCommand
+	// Reason explains why a recognized command was refused, e.g. because
+	// nothing is playing. Empty when the command was accepted.
+	Reason RejectReason
+	// MatchError is set when play option matching failed, e.g. because the
+	// options couldn't be fetched, and the query was passed through instead.
+	MatchError error
+	// Candidates holds the play options the LLM ranked for the query, best
+	// first, when ranked matching is enabled.
+	Candidates []string
+
+	match *deferredMatch // the play query still to be matched, if deferred
+}
+
+// RejectReason explains why a recognized command was not sent.
+type RejectReason string
+
+const (
+	// RejectNothingPlaying means the command only applies while something plays.
+	RejectNothingPlaying RejectReason = "nothing is playing"
+	// RejectQueueEmpty means the command only applies when tracks are queued.
+	RejectQueueEmpty RejectReason = "the queue is empty"
+	// RejectQueryTooLong means the play query exceeded the maximum length.
+	RejectQueryTooLong RejectReason = "the query is too long"
+	// RejectDisabled means the command has been disabled with SetCommandEnabled.
+	RejectDisabled RejectReason = "the command is disabled"
+	// RejectNoResults means "play the <ordinal> result" came from a user with
+	// no results to pick from.
+	RejectNoResults RejectReason = "no results"
+	// RejectNoSuchResult means "play the <ordinal> result" named an entry past
+	// the end of the user's last results.
+	RejectNoSuchResult RejectReason = "there is no such result"
+	// RejectNegated means the command was negated, as in "don't stop".
+	RejectNegated RejectReason = "the command was negated"
+	// RejectNotAdmin means a rename request came from a user not set with
+	// SetAdminUsers.
+	RejectNotAdmin RejectReason = "only admins can rename the bot"
+	// RejectInvalidName means a rename request's new name is empty, more than
+	// one word, or a command keyword.
+	RejectInvalidName RejectReason = "the new name is not allowed"
+	// RejectInvalidPosition means a seek named a clock time that isn't one,
+	// as in "skip to 1:75".
+	RejectInvalidPosition RejectReason = "the position is not a valid time"
+)
+
+// defaultMatchPrompt is the user prompt sent to the LLM when matching a play query.
+const defaultMatchPrompt = `The user said: {{printf "%q" .Query}}
+
+Available options:
+{{.Options}}
+
+Which option best matches what the user asked for? ` +
+	`Reply with ONLY the exact option name, nothing else. ` +
+	`If nothing matches, reply with the user's original query exactly as given.`
+
+// matchSystemPrompt is the system message sent alongside the match prompt.
+const matchSystemPrompt = "You are a matching assistant. Given a spoken query and a list of available options, pick the best match. Reply with only the option name, no explanation."
+
+// defaultCommandPrefix is prepended to every command sent to the text channel.
+const defaultCommandPrefix = "!"
+
+// MatchPromptData is the data available to a match prompt template.
+type MatchPromptData struct {
+	// Query is the spoken play query.
+	Query string
+	// Options is the newline-separated list of option names.
+	Options string
+	// OptionNames holds the individual option names.
+	OptionNames []string
+}
+
+// playbackNeed is the playback state a command requires to be meaningful.
+type playbackNeed int
+
+const (
+	needNothing playbackNeed = iota
+	needPlaying
+	needQueue
+)
+
+// keywordCommand maps spoken phrases to a zero-argument command.
+type keywordCommand struct {
+	name    string   // command name, sent after the command prefix
+	phrases []string // phrases matched as whole words at the start of the command
+	needs   playbackNeed
+}
+
+// keywordCommands lists the zero-argument commands in match order. Earlier entries
+// take precedence, so "stop that" cancels a pending action rather than stopping playback.
+var keywordCommands = []keywordCommand{
+	{name: "cancel", phrases: []string{"cancel", "never mind", "nevermind", "forget it", "stop that"}},
+	{name: "stop", phrases: []string{"stop"}, needs: needPlaying},
+	{name: "skip", phrases: []string{"skip", "next song", "next track"}, needs: needPlaying},
+	{name: "pause", phrases: []string{"pause"}, needs: needPlaying},
+	{name: "resume", phrases: []string{"resume", "unpause", "keep playing"}},
+	{name: "clear", phrases: []string{"clear queue", "clear the queue"}, needs: needQueue},
+	// Apostrophes are stripped before matching, so "what's" arrives as "whats".
+	{name: "np", phrases: []string{"now playing", "whats playing", "what is playing"}},
+	{name: confirmCommand, phrases: []string{"confirm", "yes", "yeah"}},
+	// Mute gets its own command rather than "!volume 0" so unmute can restore
+	// the previous level, and both stay distinct from pause/resume.
+	{name: "mute", phrases: []string{"mute"}},
+	{name: "unmute", phrases: []string{"unmute", "un mute"}},
+	// Checked before the play branch so "play previous" isn't sent as a query.
+	{name: "previous", phrases: []string{"previous", "play previous", "play the previous", "go back"}},
+	{name: shuffleCommand, phrases: []string{"shuffle"}},
+	{name: "leave", phrases: []string{"leave", "disconnect", "get out"}},
+	// Saves the now-playing track to the user's favorites.
+	{name: "save", phrases: []string{
+		"save this", "save that", "save the song", "save this song",
+		"favorite this", "favourite this", "like this", "like that",
+	}, needs: needPlaying},
+	// Adds the result previewed by the user's last search to the queue.
+	{name: queueThisCommand, phrases: []string{"queue this", "queue that", "add this", "add that"}},
+	{name: repeatCommand, phrases: []string{"again", "do that again", "do it again", "repeat that", "play that again", "one more time"}},
+	// Lists the commands, for new members finding out what the bot understands.
+	{name: helpCommand, phrases: []string{"help", "list commands", "list your commands", "what can you do", "what can i say"}},
 }
 
 // VoiceService handles voice-to-text-to-command pipeline.
 // It transcribes audio, checks for the wake phrase, and parses voice commands.
 // For "play" commands, it uses the LLM to match against available options.
 type VoiceService struct {
-	stt         bot.STTService
-	llm         bot.LLMService
-	playOptions bot.PlayOptionsService
-	wakePhrase  string
+	stt            bot.STTService
+	sttFallbacks   []bot.STTService // tried in order when stt fails
+	chunkBytes     int              // maximum audio bytes per STT call, 0 for no limit
+	preprocess     bot.AudioPreprocessor
+	llm            bot.LLMService
+	playOptions    bot.PlayOptionsService
+	playback       bot.PlaybackState
+	wakePhrase     string
+	channelWake    map[string]string   // channel ID → wake phrase override
+	alternates     map[string][]string // wake phrase → accepted alternate spellings
+	wakeRegexp     *regexp.Regexp      // overrides phrase-based wake detection when set
+	speakerLabels  *regexp.Regexp      // leading line labels stripped before parsing, nil for none
+	matchPrompt    *template.Template
+	requireWake    bool
+	minAudio       int
+	commandPrefix  string
+	prefixes       map[string]string // command name → prefix overriding commandPrefix
+	politeness     [][]string        // trailing phrases stripped from play queries
+	volumeStep     int
+	maxQuery       int // maximum play query length in characters, 0 for unlimited
+	queryOverflow  QueryOverflow
+	sanitize       QuerySanitizer
+	rewrite        CommandRewriter // applied to commands before they are returned, nil for none
+	logger         Logger
+	localMatch     bool // fuzzy-match options locally when the LLM is unavailable
+	phonetic       bool // pick the option that sounds like the query before the LLM
+	rankedMatching bool // ask the LLM for ranked options rather than one
+	llmUsage       LLMUsageHook
+	llmOff         atomic.Bool   // set by SetLLMEnabled(false)
+	llmSlots       chan struct{} // one entry per running LLM call, nil for no limit
+	llmPolicy      LLMLimitPolicy
+	aliases        []commandAlias
+	adminUsers     map[string]bool // may rename the bot by voice
+	deniedUsers    map[string]bool
+	allowedUsers   map[string]bool // empty allows everyone
+	disabled       map[string]bool // command names refused with RejectDisabled
+	turnOff        *keywordCommand // sent for "shut up" and "turn it off", nil to ignore them
+	randomSynonyms []string        // whole commands that play a random track
+	randomOutput   string          // replaces "!pr" in play random output when set
+
+	strictAdjacency bool
+	commandFillers  map[string]bool   // words allowed between the wake phrase and command
+	noiseWords      map[string]bool   // words dropped from the command before matching
+	placeholders    map[string]bool   // STT placeholder tokens dropped from transcriptions
+	homophones      map[string]string // misheard first word → command keyword
+	minCommandWords int
+	maxIntervening  int
+	fuzzyKeywords   bool
+	trailingWake    bool
+	firstWordWake   int    // edits allowed between the first word and the wake phrase, 0 to disable
+	bareWake        string // command sent for a bare wake phrase, "" for none
+	wakeSensitivity int    // edits allowed between the spoken wake word and the wake phrase
+
+	confirm        map[string]bool // command names that need confirming
+	confirmTimeout time.Duration
+	confirmTexts   map[string]*template.Template // command name → confirmation text for VoiceResult
+	clock          Clock
+
+	wakeWindow time.Duration
+
+	configMu sync.RWMutex // guards the settings above against ApplyConfig while parsing
+
+	mu         sync.Mutex // guards per-user and per-channel state below
+	pending    *stateLRU[stateKey, pendingConfirmation]
+	wakeBuffer *stateLRU[stateKey, bufferedWake]
+	previews   *stateLRU[stateKey, string]   // last search query, for "queue this"
+	candidates *stateLRU[stateKey, []string] // last ranked play candidates
+	results    *stateLRU[stateKey, []string] // last results shown, for "play the third result"
+
+	transcriptTTL time.Duration
+	transcripts   *stateLRU[transcriptionKey, cachedTranscription]
+
+	historySize  int
+	history      map[string]*commandRing // channel ID → recent commands
+	historyStore bot.HistoryStore
+	saveMu       sync.Mutex // orders history saves
+
+	indexMu  sync.Mutex
+	index    *optionIndex  // normalized names for the last fetched options
+	indexAt  time.Time     // when index was last confirmed by a fetch
+	indexTTL time.Duration // how long index may be trusted without a fetch
+
+	statsMu sync.Mutex
+	stats   VoiceStats
+}
+
+// ErrEmptyWakePhrase is returned when a wake phrase is empty or only whitespace.
+var ErrEmptyWakePhrase = errors.New("wake phrase must not be empty")
+
+// ErrInvalidWakePhrase is returned when a wake phrase has no letters or digits,
+// so no spoken word could ever match it.
+var ErrInvalidWakePhrase = errors.New("wake phrase must contain a letter or digit")
+
+// ValidateWakePhrase reports whether phrase can be used as a wake phrase.
+func ValidateWakePhrase(phrase string) error {
+	if strings.TrimSpace(phrase) == "" {
+		return ErrEmptyWakePhrase
+	}
+	if normalizeWakePhrase(phrase) == "" {
+		return ErrInvalidWakePhrase
+	}
+	return nil
+}
+
+// normalizeWakePhrase lowercases a wake phrase and trims the punctuation and
+// symbols around it, as is done to the spoken words it is compared with, so
+// "(Laser)" becomes "laser". Inner punctuation is kept: the phrase is always
+// compared literally, never used as a pattern, so "la.ser" only matches "la.ser".
+func normalizeWakePhrase(phrase string) string {
+	return strings.TrimFunc(strings.ToLower(phrase), isWordEdge)
+}
+
+// NewVoiceServiceChecked is like NewVoiceService but rejects an invalid wake phrase.
+func NewVoiceServiceChecked(stt bot.STTService, wakePhrase string, llm bot.LLMService, playOptions bot.PlayOptionsService) (*VoiceService, error) {
+	if err := ValidateWakePhrase(wakePhrase); err != nil {
+		return nil, err
+	}
+	return NewVoiceService(stt, wakePhrase, llm, playOptions), nil
 }
 
 // NewVoiceService creates a new VoiceService.
 // playOptions and llm may be nil — if so, play commands pass through the raw transcription.
 func NewVoiceService(stt bot.STTService, wakePhrase string, llm bot.LLMService, playOptions bot.PlayOptionsService) *VoiceService {
 	return &VoiceService{
-		stt:         stt,
-		llm:         llm,
-		playOptions: playOptions,
-		wakePhrase:  strings.ToLower(wakePhrase),
+		stt:            stt,
+		llm:            llm,
+		playOptions:    playOptions,
+		wakePhrase:     normalizeWakePhrase(wakePhrase),
+		channelWake:    make(map[string]string),
+		disabled:       make(map[string]bool),
+		turnOff:        defaultTurnOff(),
+		randomSynonyms: defaultRandomSynonyms,
+		confirmTexts:   parseConfirmationTemplates(),
+		logger:         nopLogger{},
+		alternates:     map[string][]string{"laser": {"lazer"}},
+		matchPrompt:    template.Must(template.New("match").Parse(defaultMatchPrompt)),
+		requireWake:    true,
+		commandPrefix:  defaultCommandPrefix,
+		prefixes:       make(map[string]string),
+		politeness:     splitPhrases(defaultPolitenessPhrases),
+		volumeStep:     defaultVolumeStep,
+		sanitize:       SanitizeQuery,
+		commandFillers: wordSet(defaultCommandFillers),
+		placeholders:   wordSet(defaultPlaceholderTokens),
+		homophones:     maps.Clone(defaultHomophones),
+		maxIntervening: defaultMaxInterveningWords,
+
+		confirmTimeout: defaultConfirmTimeout,
+		clock:          realClock{},
+		pending:        newStateLRU[stateKey, pendingConfirmation](defaultStateCapacity),
+		wakeBuffer:     newStateLRU[stateKey, bufferedWake](defaultStateCapacity),
+		previews:       newStateLRU[stateKey, string](defaultStateCapacity),
+		candidates:     newStateLRU[stateKey, []string](defaultStateCapacity),
+		results:        newStateLRU[stateKey, []string](defaultStateCapacity),
+		historySize:    defaultRecentCommands,
+		history:        make(map[string]*commandRing),
+
+		stats: VoiceStats{Commands: make(map[string]int64)},
+	}
+}
+
+// SetWakeAlternates registers alternate spellings that STT commonly produces for
+// a wake phrase (e.g. "jarvus" for "jarvis"), replacing any previously set for it.
+// "lazer" is registered for "laser" by default.
+func (s *VoiceService) SetWakeAlternates(phrase string, alternates []string) {
+	key := normalizeWakePhrase(phrase)
+	lowered := make([]string, 0, len(alternates))
+	for _, alt := range alternates {
+		if alt = normalizeWakePhrase(alt); alt != "" {
+			lowered = append(lowered, alt)
+		}
 	}
+	s.alternates[key] = lowered
+}
+
+// SetPlaybackState sets a provider that reports what the music bot is doing.
+// When set, playback controls such as stop, skip and pause are refused while
+// nothing is playing, and clearing the queue is refused when it is empty.
+func (s *VoiceService) SetPlaybackState(p bot.PlaybackState) {
+	s.playback = p
+}
+
+// SetCommandPrefix sets the prefix prepended to command output (default "!"),
+// for a downstream bot that uses e.g. "/" or a mention instead.
+func (s *VoiceService) SetCommandPrefix(prefix string) {
+	s.commandPrefix = prefix
+}
+
+// SetCommandPrefixFor overrides the command prefix for one command, e.g. "/"
+// for "leave" when moderation commands go to a different bot than music
+// commands. An empty prefix removes the override.
+func (s *VoiceService) SetCommandPrefixFor(name, prefix string) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if prefix == "" {
+		delete(s.prefixes, name)
+		return
+	}
+	s.prefixes[name] = prefix
+}
+
+// SetRequireWakePhrase controls whether commands must start with the wake phrase.
+// When disabled (e.g. for a dedicated command channel) the whole transcription is
+// treated as a command; a leading wake phrase is still accepted and skipped.
+func (s *VoiceService) SetRequireWakePhrase(require bool) {
+	s.requireWake = require
+}
+
+// SetMatchPrompt replaces the prompt used to ask the LLM which play option matches
+// a query. The template is parsed with text/template and receives MatchPromptData,
+// e.g. "Pick one of:\n{{.Options}}\nfor {{.Query}}".
+func (s *VoiceService) SetMatchPrompt(tmpl string) error {
+	t, err := template.New("match").Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("parse match prompt: %w", err)
+	}
+	s.matchPrompt = t
+	return nil
+}
+
+// SetMinAudioBytes skips transcription of audio shorter than n bytes, such as
+// clicks or coughs that would only waste an STT call. Zero disables the check.
+func (s *VoiceService) SetMinAudioBytes(n int) {
+	s.minAudio = n
+}
+
+// SetAudioPreprocessor sets a processor that HandleVoice runs on audio before
+// transcribing it. A nil processor passes audio through unchanged.
+func (s *VoiceService) SetAudioPreprocessor(p bot.AudioPreprocessor) {
+	s.preprocess = p
 }
 
 // HandleVoice transcribes audio and parses voice commands.
 // Returns the command text to send to chat, or empty string if no valid command.
+// If ctx is cancelled before or between the STT and LLM calls, ctx.Err() is returned.
+// The audio is assumed to be in DefaultAudioFormat; see HandleVoiceWithFormat.
 func (s *VoiceService) HandleVoice(ctx context.Context, channelID, userID string, audioWAV []byte) (string, error) {
-	text, err := s.stt.Transcribe(ctx, audioWAV)
-	if err != nil {
-		return "", fmt.Errorf("transcribe audio: %w", err)
+	result, err := s.HandleVoiceDetailed(ctx, channelID, userID, audioWAV)
+	return result.Command, err
+}
+
+// HandleVoiceDetailed is like HandleVoice but also returns the transcription and
+// the outcome, so callers can record what was said and respond differently to
+// silence, speech without the wake phrase and an unrecognized command.
+func (s *VoiceService) HandleVoiceDetailed(ctx context.Context, channelID, userID string, audioWAV []byte) (VoiceResult, error) {
+	return s.handleVoice(ctx, channelID, userID, audioWAV, DefaultAudioFormat)
+}
+
+// handleVoice does the work behind HandleVoice and its variants.
+func (s *VoiceService) handleVoice(ctx context.Context, channelID, userID string, audio []byte, format bot.AudioFormat) (VoiceResult, error) {
+	var result VoiceResult
+	if s.userIgnored(userID) {
+		result.Outcome = OutcomeIgnoredUser
+		return result, nil
+	}
+	s.configMu.RLock()
+	minAudio := s.minAudio
+	s.configMu.RUnlock()
+	if len(audio) < minAudio {
+		result.Outcome = OutcomeNoSpeech
+		return result, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	cacheKey, text, cached := s.cachedTranscript(audio, format)
+	if !cached {
+		if s.preprocess != nil {
+			processed, err := s.preprocess.Process(ctx, audio)
+			if err != nil {
+				s.logger.Warn("audio preprocessing failed", "channel", channelID, "user", userID, "error", err)
+				return result, fmt.Errorf("preprocess audio: %w", err)
+			}
+			audio = processed
+		}
+
+		var err error
+		text, err = s.transcribeChunked(ctx, audio, format)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, ctxErr
+		}
+		if err != nil {
+			s.logger.Warn("transcription failed", "channel", channelID, "user", userID, "error", err)
+			return result, fmt.Errorf("transcribe audio: %w", err)
+		}
+		s.cacheTranscript(cacheKey, text)
 	}
 
 	text = strings.TrimSpace(text)
 	if text == "" {
-		return "", nil
+		result.Outcome = OutcomeNoSpeech
+		return result, nil
 	}
+	result.Transcription = text
 
 	log.Printf("voice transcription from user %s: %s", userID, text)
+	s.logger.Info("transcription received", "channel", channelID, "user", userID, "text", text, "cached", cached)
+
+	// A rename needs the write lock, so it is applied once the parse is done.
+	var rename string
+	defer func() {
+		if rename != "" {
+			s.applyRename(channelID, userID, rename)
+		}
+	}()
+	// The parse reads the runtime settings, but matching a play query waits on
+	// the options source and the LLM, so that runs without configMu held.
+	key := userKey(channelID, userID)
+	s.configMu.RLock()
+	trace, ok := s.parseBuffered(withDeferredMatch(ctx), key, text)
+	s.configMu.RUnlock()
+	if ok {
+		trace = s.matchDeferred(ctx, trace)
+	}
+	// LLM matching falls back to passthrough on error, so check for cancellation
+	// rather than sending a command the caller no longer wants.
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if ok && trace.Command.Name == renameCommand && !s.adminUsers[userID] {
+		trace.Reason = RejectNotAdmin
+		ok = false
+	}
+	s.recordStats(trace, ok)
+	if trace.WakeFound {
+		s.logger.Debug("wake phrase matched", "channel", channelID, "user", userID,
+			"wake", trace.Command.WakeToken, "filler", trace.Command.FillerPrefix)
+	}
+	if !ok {
+		result.Outcome = OutcomeNoWakePhrase
+		if trace.WakeFound {
+			result.Outcome = OutcomeNoCommandAfterWake
+			result.Reason = trace.Reason
+		}
+		s.logger.Debug("no command", "channel", channelID, "user", userID, "outcome", string(result.Outcome), "reason", string(trace.Reason))
+		return result, nil
+	}
 
-	cmd, ok := s.parseCommand(ctx, text)
+	result.Outcome = OutcomeNoCommandAfterWake
+	s.configMu.RLock()
+	cmd, reason, ok := s.resolveCommand(key, trace)
+	s.configMu.RUnlock()
 	if !ok {
-		return "", nil
+		result.Reason = reason
+		if cmd.RequiresConfirmation {
+			log.Printf("voice command from user %s awaiting confirmation: %s", userID, cmd.Text)
+			result.Outcome = OutcomeAwaitingConfirmation
+		}
+		return result, nil
+	}
+
+	if cmd.Name == renameCommand {
+		rename = renameTarget(cmd)
+		result.Outcome = OutcomeWakePhraseChanged
+		result.Confirmation = s.ConfirmationText(cmd)
+		return result, nil
 	}
 
 	log.Printf("voice command from user %s: %s", userID, cmd.Text)
-	return cmd.Text, nil
+	if s.recordCommand(channelID, userID, cmd) {
+		// The command is already decided, so persist it even if ctx ends now.
+		s.saveHistory(context.WithoutCancel(ctx), channelID)
+	}
+	s.rememberPreview(key, trace, cmd)
+	s.rememberCandidates(key, trace, cmd)
+	s.rememberResults(key, trace, cmd)
+	if cmd, ok = s.rewriteCommand(userID, cmd); !ok {
+		result.Outcome = OutcomeSuppressed
+		return result, nil
+	}
+	result.Command = cmd.Text
+	result.Outcome = OutcomeMatched
+	result.Confirmation = s.ConfirmationText(cmd)
+	s.logger.Info("command matched", "channel", channelID, "user", userID,
+		"command", cmd.Name, "text", cmd.Text, "branch", string(trace.Branch))
+	return result, nil
+}
+
+// resolveCommand returns the command to send for the trace: the match of a
+// deferred play query, or the command that "again", "queue this" or "play the
+// third result" stands for. It returns false if there is none, with the
+// reason if one was refused, or the command if it is held for confirmation.
+// The caller must hold configMu.
+func (s *VoiceService) resolveCommand(key stateKey, trace CommandTrace) (VoiceCommand, RejectReason, bool) {
+	cmd, ok := s.resolveRepeat(key, s.finishMatch(trace))
+	if !ok {
+		return VoiceCommand{}, "", false
+	}
+	if cmd, ok = s.resolveQueueThis(key, cmd); !ok {
+		return VoiceCommand{}, "", false
+	}
+	cmd, reason := s.resolveResult(key, cmd)
+	if reason != "" {
+		return VoiceCommand{}, reason, false
+	}
+	cmd, ok = s.resolveConfirmation(key, cmd)
+	return cmd, "", ok
+}
+
+// stateKey identifies a user's per-channel state.
+type stateKey struct {
+	channelID string
+	userID    string
+}
+
+// userKey returns the key for a user's state in a channel.
+func userKey(channelID, userID string) stateKey {
+	return stateKey{channelID: channelID, userID: userID}
+}
+
+// Stats returns a snapshot of the voice command counters.
+func (s *VoiceService) Stats() VoiceStats {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	snapshot := s.stats
+	snapshot.Commands = make(map[string]int64, len(s.stats.Commands))
+	for name, n := range s.stats.Commands {
+		snapshot.Commands[name] = n
+	}
+	return snapshot
+}
+
+// recordStats counts a parsed transcription and, if one matched, its command.
+func (s *VoiceService) recordStats(trace CommandTrace, matched bool) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	s.stats.Transcriptions++
+	if trace.MatchError != nil {
+		s.stats.MatchErrors++
+	}
+	if !matched {
+		s.stats.NoMatch++
+		return
+	}
+	s.stats.Matched++
+	s.stats.Commands[trace.Command.Name]++
+}
+
+// Explain parses a transcription like HandleVoice would and returns the decision
+// trace, without sending anything. It may still consult play options and the LLM
+// to resolve play queries, but doesn't update the cached option index, take an
+// LLM concurrency slot or call the LLM usage hook. Returns false if no command
+// would be produced.
+func (s *VoiceService) Explain(ctx context.Context, transcription string) (CommandTrace, bool) {
+	return s.explainMatched(withDryRun(ctx), transcription)
+}
+
+// ParseBatch parses each transcription like Explain and returns the results in
+// input order. Like Explain it leaves counters, per-user state, the cached
+// option index and the LLM limit untouched, so it can be used to
+// regression-test a corpus of transcriptions.
+func (s *VoiceService) ParseBatch(ctx context.Context, transcriptions []string) []ParseResult {
+	ctx = withDryRun(ctx)
+
+	results := make([]ParseResult, len(transcriptions))
+	for i, transcription := range transcriptions {
+		results[i].Input = transcription
+		if trace, ok := s.explainMatched(ctx, transcription); ok {
+			results[i].Command = trace.Command.Text
+			results[i].Branch = trace.Branch
+		}
+	}
+	return results
 }
 
 // parseCommand checks if the transcription contains the wake phrase
 // (optionally preceded by filler words like "hey", "yo") and parses the subsequent command.
 func (s *VoiceService) parseCommand(ctx context.Context, transcription string) (VoiceCommand, bool) {
-	lower := strings.ToLower(transcription)
+	trace, ok := s.explain(ctx, "", transcription)
+	if !ok {
+		return VoiceCommand{}, false
+	}
+	return trace.Command, true
+}
+
+// ParseCommands parses a transcription that may contain several commands joined
+// by "and" or "then" (e.g. "laser stop and play random") and returns them in
+// spoken order. A conjunction only splits when the words after it start a
+// command, so "play rock and roll" stays a single play query.
+func (s *VoiceService) ParseCommands(ctx context.Context, transcription string) []VoiceCommand {
+	var commands []VoiceCommand
+	traces, _ := s.explainAllMatched(ctx, transcription)
+	for _, trace := range traces {
+		if trace.Reason == "" {
+			commands = append(commands, trace.Command)
+		}
+	}
+	return commands
+}
+
+// explain does the work behind parseCommand and Explain, returning the first
+// command of a compound utterance. An empty channelID uses the global wake phrase.
+func (s *VoiceService) explain(ctx context.Context, channelID, transcription string) (CommandTrace, bool) {
+	traces, woke := s.explainAll(ctx, channelID, transcription)
+	return firstCommand(traces, woke, transcription)
+}
+
+// explainAllMatched is explainAll for the exported parse methods, using the
+// global wake phrase. It holds configMu only while parsing and while building
+// the commands, not while play queries are matched, so ApplyConfig never waits
+// on the options source or the LLM. The caller must not hold configMu.
+func (s *VoiceService) explainAllMatched(ctx context.Context, transcription string) ([]CommandTrace, bool) {
+	s.configMu.RLock()
+	traces, woke := s.explainAll(withDeferredMatch(ctx), "", transcription)
+	s.configMu.RUnlock()
+
+	for i := range traces {
+		traces[i] = s.matchDeferred(ctx, traces[i])
+	}
+
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	for i := range traces {
+		traces[i].Command = s.finishMatch(traces[i])
+		traces[i].match = nil
+	}
+	return traces, woke
+}
+
+// explainMatched is explain for the exported parse methods, matching play
+// queries like explainAllMatched.
+func (s *VoiceService) explainMatched(ctx context.Context, transcription string) (CommandTrace, bool) {
+	traces, woke := s.explainAllMatched(ctx, transcription)
+	return firstCommand(traces, woke, transcription)
+}
+
+// firstCommand returns the first command of a compound utterance's traces,
+// or the first refused one, or else an empty trace for the transcription.
+func firstCommand(traces []CommandTrace, woke bool, transcription string) (CommandTrace, bool) {
+	for _, trace := range traces {
+		if trace.Reason == "" {
+			return trace, true
+		}
+	}
+	if len(traces) > 0 {
+		return traces[0], false
+	}
+	return CommandTrace{Transcription: transcription, WakeFound: woke}, false
+}
+
+// explainAll resolves every command in the transcription, in order. Commands
+// that were recognized but refused are included with their Reason set. It also
+// reports whether the wake phrase was found.
+func (s *VoiceService) explainAll(ctx context.Context, channelID, transcription string) ([]CommandTrace, bool) {
+	phrase := s.wakePhraseFor(channelID)
+	remainder, wake, ok := s.commandText(phrase, transcription)
+	if !ok {
+		return nil, false
+	}
+	remainder = s.dropNoise(remainder)
+	bare := len(remainder.words) == 0 && wake.token != ""
+	if !bare && len(remainder.words) < s.minCommandWords {
+		return nil, true
+	}
+
+	var traces []CommandTrace
+	for _, segment := range s.splitCompound(remainder) {
+		var trace CommandTrace
+		var ok bool
+		if bare {
+			trace, ok = s.bareWakeTrace()
+		} else {
+			trace, ok = s.matchCommand(ctx, segment)
+		}
+		if !ok && trace.Reason == "" {
+			continue
+		}
+		if ok && s.commandDisabled(trace.Command.Name) {
+			trace.Reason = RejectDisabled
+		}
+		trace.Transcription = transcription
+		trace.WakeFound = true
+		trace.Command.WakeToken = wake.token
+		trace.Command.FillerPrefix = wake.filler
+		s.markConfirmation(&trace.Command, phrase)
+		traces = append(traces, trace)
+	}
+	return traces, true
+}
+
+// commandWords is a run of words following the wake phrase. Matching uses the
+// lowercase, punctuation-free form; spoken keeps the original casing so queries
+// can be passed on as the user said them.
+type commandWords struct {
+	words  []string
+	spoken []string
+}
+
+// newCommandWords strips punctuation from each word (STT may transcribe "Stop!"
+// or "stop.") and drops words left empty. The spoken form only loses leading
+// and trailing punctuation, so titles like "lo-fi" or "AC/DC" survive.
+func newCommandWords(fields []string) commandWords {
+	cw := commandWords{
+		words:  make([]string, 0, len(fields)),
+		spoken: make([]string, 0, len(fields)),
+	}
+	for _, field := range fields {
+		// Most words are already plain lowercase and can be used as they are.
+		if isPlainWord(field) {
+			cw.words = append(cw.words, field)
+			cw.spoken = append(cw.spoken, field)
+			continue
+		}
+		var word strings.Builder
+		for _, r := range field {
+			lower := unicode.ToLower(r)
+			if (lower >= 'a' && lower <= 'z') || (lower >= '0' && lower <= '9') {
+				word.WriteRune(lower)
+			}
+		}
+		if word.Len() == 0 {
+			continue
+		}
+		cw.words = append(cw.words, word.String())
+		cw.spoken = append(cw.spoken, spokenWord(field))
+	}
+	return cw
+}
+
+// spokenWord trims punctuation from a field, keeping the sign of a signed
+// number such as "-5" so it isn't mistaken for an unsigned one.
+func spokenWord(field string) string {
+	spoken := strings.TrimFunc(field, isWordEdge)
+	signed := strings.TrimRightFunc(field, isWordEdge)
+	if len(signed) == len(spoken)+1 && (signed[0] == '-' || signed[0] == '+') && isDigits(spoken) {
+		return signed
+	}
+	return spoken
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// transcriptFields splits a transcription into words. Besides whitespace, a
+// comma separates words, as in "laser,stop", unless it sits between digits as
+// in "10,000". Fields that are only punctuation, like a lone "-", are dropped.
+func transcriptFields(transcription string) []string {
+	if strings.IndexByte(transcription, ',') >= 0 {
+		b := []byte(transcription)
+		for i, c := range b {
+			if c == ',' && !(i > 0 && isDigit(b[i-1]) && i+1 < len(b) && isDigit(b[i+1])) {
+				b[i] = ' '
+			}
+		}
+		transcription = string(b)
+	}
+	fields := strings.Fields(transcription)
+	kept := fields[:0]
+	for _, f := range fields {
+		if strings.IndexFunc(f, isWordRune) >= 0 {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// isDigit reports whether c is an ASCII digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isWordRune reports whether r is a letter or digit.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isPlainWord reports whether field consists only of lowercase ASCII letters
+// and digits, so its matching and spoken forms are the field itself.
+func isPlainWord(field string) bool {
+	for i := 0; i < len(field); i++ {
+		if c := field[i]; (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isWordEdge reports whether r is punctuation or a symbol to trim from the
+// ends of a spoken word.
+func isWordEdge(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// text returns the words in matching form, separated by single spaces.
+func (cw commandWords) text() string {
+	return strings.Join(cw.words, " ")
+}
+
+// spokenFrom returns the spoken form of the words starting at index i.
+func (cw commandWords) spokenFrom(i int) string {
+	return strings.Join(cw.spoken[i:], " ")
+}
 
-	// Normalize common alternate spellings (e.g. "lazer" → "laser")
-	normalized := strings.NewReplacer("lazer", "laser").Replace(lower)
+// slice returns the words in [from, to).
+func (cw commandWords) slice(from, to int) commandWords {
+	return commandWords{words: cw.words[from:to], spoken: cw.spoken[from:to]}
+}
+
+// wakeMatch records how the wake phrase was spoken.
+type wakeMatch struct {
+	token  string // wake phrase variant, lowercase
+	filler string // filler words before it, lowercase without punctuation
+}
+
+// commandText finds the wake phrase and returns the words that follow it.
+func (s *VoiceService) commandText(phrase, transcription string) (commandWords, wakeMatch, bool) {
+	transcription = s.stripSpeakerLabels(transcription)
+	if s.wakeRegexp != nil {
+		if cw, wake, ok := s.matchWakeRegexp(transcription); ok || s.requireWake {
+			return cw, wake, ok
+		}
+		return newCommandWords(s.transcriptWords(transcription)), wakeMatch{}, true
+	}
+
+	fields := s.transcriptWords(transcription)
+	// Only the words that may hold the wake phrase need lowercasing. STT often
+	// punctuates around it ("Laser, stop."), so that is trimmed too.
+	lower := make([]string, min(len(fields), maxWakeFillers+1))
+	for i := range lower {
+		lower[i] = strings.TrimFunc(strings.ToLower(fields[i]), isWordEdge)
+	}
 
 	// Find wake phrase as a whole word, allowing up to 2 filler words before it
-	rest, found := s.extractAfterWakePhrase(normalized)
+	i, found := s.findWakePhrase(phrase, lower)
 	if !found {
-		return VoiceCommand{}, false
+		if cw, wake, ok := s.firstWordWakeText(phrase, fields, lower); ok {
+			return cw, wake, true
+		}
+	}
+	// A wake phrase ending a short command ("stop laser") looks like a leading
+	// one with filler words before it, so trailing detection gets a look first.
+	if !found || i == len(fields)-1 {
+		if cw, wake, ok := s.trailingWakeText(phrase, fields); ok {
+			return cw, wake, true
+		}
 	}
+	if !found {
+		if s.requireWake {
+			return commandWords{}, wakeMatch{}, false
+		}
+		return newCommandWords(fields), wakeMatch{}, true
+	}
+	wake := wakeMatch{
+		token:  lower[i],
+		filler: newCommandWords(lower[:i]).text(),
+	}
+	return s.skipLeadIn(newCommandWords(fields[i+1:])), wake, true
+}
 
-	// Strip punctuation for command matching (STT may transcribe "Stop!" or "stop.")
-	stripped := strings.Map(func(r rune) rune {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == ' ' {
-			return r
+// matchCommand resolves a single command from the words following the wake phrase.
+func (s *VoiceService) matchCommand(ctx context.Context, cw commandWords) (CommandTrace, bool) {
+	cw, homophone := s.replaceHomophone(cw)
+	cw, shuffle := leadingShuffle(cw)
+	text := cw.text()
+	trace := CommandTrace{Remainder: text, Homophone: homophone}
+
+	if s.negatedCommand(cw) {
+		trace.Reason = RejectNegated
+		return trace, false
+	}
+
+	if a := s.matchAlias(text, true); a != nil {
+		return s.keywordTrace(trace, a.command)
+	}
+	// Checked before the keywords so "skip to 1:30" seeks rather than skips.
+	if position, ok := parseSeek(cw); ok {
+		return s.seekTrace(trace, position)
+	}
+	for _, kc := range keywordCommands {
+		if hasAnyPhrasePrefix(text, kc.phrases) {
+			return s.keywordTrace(trace, kc)
 		}
-		return -1
-	}, rest)
-	stripped = strings.TrimSpace(stripped)
+	}
+	if kc, ok := s.matchTurnOff(text); ok {
+		return s.keywordTrace(trace, kc)
+	}
+	if name, ok := s.parseRename(cw); ok {
+		return s.renameTrace(trace, name)
+	}
+	if direction, ok := parseWakeSensitivity(text); ok {
+		trace.Branch = BranchKeyword
+		trace.Command = s.command(wakeSensitivityCommand, direction)
+		return trace, true
+	}
+	if s.isRandomRequest(cw) {
+		trace.Branch = BranchKeyword
+		trace.Command = s.command("pr")
+		return trace, true
+	}
+
+	if change, signed := signedVolume(cw); signed {
+		if change == "" {
+			return trace, false
+		}
+		trace.Branch = BranchKeyword
+		trace.Command = s.commandWith("volume", map[string]string{"change": change})
+		return trace, true
+	}
+	if level, ok := parseVolume(text); ok {
+		trace.Branch = BranchKeyword
+		trace.Command = s.commandWith("volume", map[string]string{"level": strconv.Itoa(level)})
+		return trace, true
+	}
+	if change, ok := s.parseRelativeVolume(text); ok {
+		trace.Branch = BranchKeyword
+		trace.Command = s.commandWith("volume", map[string]string{"change": change})
+		return trace, true
+	}
+
+	if a := s.matchAlias(text, false); a != nil {
+		return s.keywordTrace(trace, a.command)
+	}
 
+	verbLen, isPlay := playVerbLength(cw.words)
 	switch {
-	case strings.HasPrefix(stripped, "stop"):
-		return VoiceCommand{Text: "!stop"}, true
+	case isPlay:
+		if len(cw.words) == verbLen {
+			return trace, false
+		}
+		args, shuffled := stripShuffle(cw.slice(verbLen, len(cw.words)))
+		shuffle = shuffle || shuffled
+		// "play on shuffle" leaves nothing to play, so it's the plain shuffle command.
+		if len(args.words) == 0 {
+			trace.Branch = BranchKeyword
+			trace.Command = s.command(shuffleCommand)
+			return trace, true
+		}
+		if n, ok := resultOrdinal(s.trimPoliteness(args).words); ok {
+			return s.resultTrace(trace, n, shuffle)
+		}
+		// "play my <name> playlist" names a playlist rather than a track, so it
+		// skips option matching.
+		if name, ok := playlistName(s.trimPoliteness(args)); ok {
+			if name = s.sanitizeQuery(name); name == "" {
+				return trace, false
+			}
+			trace.Query = name
+			trace.Branch = BranchKeyword
+			if name, ok = s.limitQuery(name); !ok {
+				trace.Command = s.command("playlist")
+				trace.Reason = RejectQueryTooLong
+				return trace, false
+			}
+			trace.Command = s.playCommand("playlist", name, shuffle)
+			return trace, true
+		}
+		if strings.Contains(args.text(), "random") {
+			// "play a random rock song" keeps "rock" as a filter.
+			trace.Branch = BranchKeyword
+			trace.Command = s.command("pr", randomFilters(s.trimPoliteness(args))...)
+			return trace, true
+		}
+		spoken := s.spokenQuery(args)
+		if spoken == "" {
+			return trace, false
+		}
+		query, ok := s.limitQuery(spoken)
+		if !ok {
+			trace.Query = spoken
+			trace.Command = s.command("play")
+			trace.Reason = RejectQueryTooLong
+			return trace, false
+		}
+		// A disabled play command is refused later anyway; don't spend an LLM call on it.
+		if s.commandDisabled("play") {
+			trace.Query = query
+			trace.Branch = BranchPassthrough
+			trace.Command = s.playCommand("play", query, shuffle)
+			return trace, true
+		}
+		if isDeferredMatch(ctx) {
+			trace.Query = query
+			trace.Branch = BranchPassthrough
+			trace.Command = s.playCommand("play", query, shuffle)
+			trace.match = &deferredMatch{shuffle: shuffle, settings: s.matchSettings()}
+			return trace, true
+		}
+		matched, candidates, branch, err := s.matchPlayQuery(ctx, query, s.matchSettings())
+		trace.Query = query
+		trace.Candidates = candidates
+		trace.Branch = branch
+		trace.MatchError = err
+		trace.Command = s.playCommand("play", matched, shuffle)
+		return trace, true
 
-	case strings.HasPrefix(stripped, "play"):
-		query := strings.TrimSpace(stripped[len("play"):])
-		if query == "" {
-			return VoiceCommand{}, false
+	// "search <query>" previews results without playing, so the query is
+	// passed through rather than matched against the play options.
+	case len(cw.words) > 0 && cw.words[0] == "search":
+		if len(cw.words) == 1 {
+			return trace, false
+		}
+		spoken := s.spokenQuery(cw.slice(1, len(cw.words)))
+		if spoken == "" {
+			return trace, false
 		}
-		if strings.Contains(query, "random") {
-			return VoiceCommand{Text: "!pr"}, true
+		trace.Query = spoken
+		trace.Branch = BranchPassthrough
+		query, ok := s.limitQuery(spoken)
+		if !ok {
+			trace.Command = s.command("search")
+			trace.Reason = RejectQueryTooLong
+			return trace, false
 		}
-		matched := s.matchPlayQuery(ctx, query)
-		return VoiceCommand{Text: "!play " + matched}, true
+		trace.Query = query
+		trace.Command = s.commandWith("search", map[string]string{"query": query})
+		return trace, true
 	}
 
-	return VoiceCommand{}, false
+	// Near-miss keywords are only tried once nothing matched exactly, so a
+	// play or search query is never mistaken for a command.
+	if s.fuzzyKeywords {
+		if kc, ok := fuzzyKeyword(cw.words); ok {
+			return s.keywordTrace(trace, kc)
+		}
+	}
+	return trace, false
+}
+
+// keywordTrace completes the trace for a matched keyword command, refusing it
+// if it doesn't apply to the current playback state.
+func (s *VoiceService) keywordTrace(trace CommandTrace, kc keywordCommand) (CommandTrace, bool) {
+	trace.Branch = BranchKeyword
+	trace.Command = s.command(kc.name)
+	if kc.name == helpCommand {
+		trace.Command.Args = s.helpArgs()
+	}
+	if trace.Reason = s.playbackRejection(kc.needs); trace.Reason != "" {
+		return trace, false
+	}
+	return trace, true
 }
 
-// extractAfterWakePhrase finds the wake phrase in the text and returns everything
-// after it. Allows up to 2 filler words before the wake phrase (e.g. "hey laser",
-// "yo laser"). The wake phrase must appear as a whole word — "blazer" won't match "laser".
-func (s *VoiceService) extractAfterWakePhrase(text string) (string, bool) {
-	words := strings.Fields(text)
+// playbackRejection reports why a command with the given need can't run in the
+// current playback state, or "" if it can (or no state provider is set).
+func (s *VoiceService) playbackRejection(need playbackNeed) RejectReason {
+	if s.playback == nil {
+		return ""
+	}
+	switch {
+	case need == needPlaying && !s.playback.IsPlaying():
+		return RejectNothingPlaying
+	case need == needQueue && !s.playback.HasQueue():
+		return RejectQueueEmpty
+	}
+	return ""
+}
+
+// command builds the named command, rendering its text with the command's
+// prefix followed by any arguments, e.g. "!play some song".
+func (s *VoiceService) command(name string, args ...string) VoiceCommand {
+	prefix, ok := s.prefixes[name]
+	if !ok {
+		prefix = s.commandPrefix
+	}
+	text := prefix + name
+	if name == "pr" && s.randomOutput != "" {
+		text = s.randomOutput
+	}
+	if len(args) > 0 {
+		text += " " + strings.Join(args, " ")
+	}
+	return VoiceCommand{Name: name, Text: text}
+}
+
+// playlistName extracts the name from "my <name> playlist", keeping its spoken
+// casing. Returns false if the words don't follow that pattern, and an empty
+// name for a bare "my playlist".
+func playlistName(cw commandWords) (string, bool) {
+	n := len(cw.words)
+	if n < 2 || cw.words[0] != "my" || cw.words[n-1] != "playlist" {
+		return "", false
+	}
+	return cw.slice(1, n-1).spokenFrom(0), true
+}
+
+// volumePrefixes start a numeric volume command, e.g. "set the volume to fifty".
+var volumePrefixes = []string{"set the volume", "set volume", "volume"}
+
+// volumeLevelWords name the ends of the volume range, as in "max volume" or
+// "volume to min".
+var volumeLevelWords = map[string]int{"max": 100, "maximum": 100, "full": 100, "min": 0, "minimum": 0}
+
+// parseVolume extracts a 0–100 level from a volume command such as "volume 50",
+// "volume to fifty", "set the volume at twenty five percent" or "max volume".
+func parseVolume(text string) (int, bool) {
+	if level, ok := leadingVolumeLevel(text); ok {
+		return level, true
+	}
+	for _, prefix := range volumePrefixes {
+		if !hasAnyPhrasePrefix(text, []string{prefix}) {
+			continue
+		}
+		args := strings.Fields(normalizeSpokenNumbers(strings.TrimPrefix(text, prefix)))
+		if len(args) > 0 && (args[0] == "to" || args[0] == "at") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			return 0, false
+		}
+		if level, ok := volumeLevelWords[args[0]]; ok {
+			return level, true
+		}
+		level, err := strconv.Atoi(args[0])
+		if err != nil || level < 0 || level > 100 {
+			return 0, false
+		}
+		return level, true
+	}
+	return 0, false
+}
+
+// leadingVolumeLevel parses a level word followed by "volume", e.g. "full volume".
+func leadingVolumeLevel(text string) (int, bool) {
+	first, rest, _ := strings.Cut(text, " ")
+	if second, _, _ := strings.Cut(rest, " "); second != "volume" {
+		return 0, false
+	}
+	level, ok := volumeLevelWords[first]
+	return level, ok
+}
+
+// splitCompound splits command words on "and" / "then" wherever the following
+// words start another command. Words without such a conjunction are returned whole.
+func (s *VoiceService) splitCompound(cw commandWords) []commandWords {
+	words := cw.words
+	var text string
+	var offsets []int // computed at the first conjunction, as most commands have none
+	var segments []commandWords
+	start := 0
+	for i := 0; i < len(words); i++ {
+		if words[i] != "and" && words[i] != "then" {
+			continue
+		}
+		next := i + 1
+		if words[i] == "and" && next < len(words) && words[next] == "then" {
+			next++
+		}
+		if offsets == nil {
+			text, offsets = cw.text(), wordOffsets(words)
+		}
+		if next >= len(words) || !s.startsCommand(text[offsets[next]:]) {
+			continue
+		}
+		segments = append(segments, cw.slice(start, i))
+		start = next
+		i = next - 1
+	}
+	return append(segments, cw.slice(start, len(words)))
+}
+
+// startsCommand reports whether text begins with a recognizable command keyword
+// or a registered alias.
+func (s *VoiceService) startsCommand(text string) bool {
+	return s.startsBuiltinCommand(text) || s.startsAlias(text)
+}
+
+// startsBuiltinCommand reports whether text begins with a built-in command
+// keyword or is one of the configured random synonyms.
+func (s *VoiceService) startsBuiltinCommand(text string) bool {
+	for _, kc := range keywordCommands {
+		if hasAnyPhrasePrefix(text, kc.phrases) {
+			return true
+		}
+	}
+	if _, ok := leadingVolumeLevel(text); ok {
+		return true
+	}
+	return startsPlayVerb(text) || strings.HasPrefix(text, "search ") ||
+		hasAnyPhrasePrefix(text, volumePrefixes) || startsRelativeVolume(text) ||
+		hasAnyPhrasePrefix(text, turnOffPhrases) || slices.Contains(s.randomSynonyms, text) ||
+		startsWakeSensitivity(text) || hasAnyPhrasePrefix(text, renamePhrases) ||
+		hasAnyPhrasePrefix(text, seekPhrases)
+}
+
+// hasAnyPhrasePrefix reports whether text starts with one of the phrases as whole
+// words, so "stop it" matches "stop" but "stopwatch" does not.
+func hasAnyPhrasePrefix(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if hasPhrasePrefix(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPhrasePrefix reports whether text starts with phrase as whole words.
+func hasPhrasePrefix(text, phrase string) bool {
+	return strings.HasPrefix(text, phrase) && (len(text) == len(phrase) || text[len(phrase)] == ' ')
+}
+
+// wordOffsets returns the byte offset of each word in strings.Join(words, " "),
+// so the text from any word onwards can be sliced without joining again.
+func wordOffsets(words []string) []int {
+	offsets := make([]int, len(words))
+	pos := 0
+	for i, w := range words {
+		offsets[i] = pos
+		pos += len(w) + 1
+	}
+	return offsets
+}
+
+// maxWakeFillers is how many filler words may come before the wake phrase.
+const maxWakeFillers = 2
+
+// findWakePhrase returns the index of phrase (or one of its alternate
+// spellings) in the lowercase words. Allows up to 2 filler words before the wake
+// phrase (e.g. "hey laser", "yo laser"). The wake phrase must appear as a whole
+// word — "blazer" won't match "laser".
+func (s *VoiceService) findWakePhrase(phrase string, words []string) (int, bool) {
 	for i, word := range words {
-		if word == s.wakePhrase {
-			if i > 2 {
-				return "", false
+		if s.isWakeWord(phrase, word) {
+			if i > maxWakeFillers {
+				return 0, false
 			}
-			return strings.Join(words[i+1:], " "), true
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// isWakeWord reports whether word is phrase, one of its registered alternates,
+// or close enough to phrase for the wake sensitivity.
+func (s *VoiceService) isWakeWord(phrase, word string) bool {
+	if word == phrase {
+		return true
+	}
+	for _, alt := range s.alternates[phrase] {
+		if word == alt {
+			return true
 		}
 	}
-	return "", false
+	return s.nearWakeWord(phrase, word)
 }
 
 // matchPlayQuery tries to match a spoken query against the available play options
-// using the LLM. Falls back to the raw query if matching is unavailable.
-func (s *VoiceService) matchPlayQuery(ctx context.Context, query string) string {
-	if s.playOptions == nil || s.llm == nil {
-		return query
+// using the LLM, or the local matcher if enabled and the LLM is unavailable.
+// Falls back to the raw query if matching is unavailable. The returned branch
+// reports which of them produced the result. A non-nil error explains a
+// fallback caused by a failure, such as GetOptions erroring; the returned
+// query is still usable. With ranked matching enabled, the LLM's ranked
+// options are returned as candidates, best (the returned query) first. ms
+// holds the runtime settings it needs, so it can run without configMu.
+func (s *VoiceService) matchPlayQuery(ctx context.Context, query string, ms matchSettings) (string, []string, MatchBranch, error) {
+	llm := s.activeLLM()
+	if s.playOptions == nil || (llm == nil && !ms.local && !ms.phonetic) {
+		return query, nil, BranchPassthrough, nil
+	}
+	// A query naming one of the options fetched recently is sent without
+	// waiting for them to be fetched again.
+	cached := s.cachedOptionIndex()
+	if cached != nil {
+		if option, ok := cached.containedIn(query); ok {
+			s.logger.Debug("query contains cached play option", "query", query, "option", option.Name)
+			return option.Name, nil, BranchSubstring, nil
+		}
 	}
 
 	options, err := s.playOptions.GetOptions(ctx)
 	if err != nil {
-		log.Printf("failed to get play options for matching: %v", err)
-		return query
+		log.Printf("failed to get play options for matching, using raw query: %v", err)
+		s.logger.Warn("get play options failed", "query", query, "error", err)
+		return query, nil, BranchPassthrough, fmt.Errorf("get play options: %w", err)
 	}
 
-	if len(options) == 0 {
-		return query
+	if len(options) == 0 || ctx.Err() != nil {
+		return query, nil, BranchPassthrough, nil
 	}
-
-	// Build the options list for the LLM prompt
-	var optionNames []string
-	for _, opt := range options {
-		optionNames = append(optionNames, opt.Name)
+	// A query naming an option outright doesn't need the LLM. Unchanged
+	// options were already checked above.
+	index := s.indexFor(ctx, options)
+	if index != cached {
+		if option, ok := index.containedIn(query); ok {
+			s.logger.Debug("query contains play option", "query", query, "option", option.Name)
+			return option.Name, nil, BranchSubstring, nil
+		}
+	}
+	if ms.phonetic {
+		if i, ok := index.soundsLike(query); ok {
+			name := index.options[i].Name
+			log.Printf("phonetically matched %q -> %q", query, name)
+			return name, nil, BranchPhonetic, nil
+		}
+	}
+	if llm == nil && !ms.local {
+		return query, nil, BranchPassthrough, nil
+	}
+	if llm == nil {
+		matched, branch := s.matchLocal(query, index)
+		return matched, nil, branch, nil
 	}
-	optionsList := strings.Join(optionNames, "\n")
-
-	prompt := fmt.Sprintf(
-		"The user said: %q\n\n"+
-			"Available options:\n%s\n\n"+
-			"Which option best matches what the user asked for? "+
-			"Reply with ONLY the exact option name, nothing else. "+
-			"If nothing matches, reply with the user's original query exactly as given.",
-		query, optionsList,
-	)
 
-	messages := []bot.LLMMessage{
-		{Role: "system", Content: "You are a matching assistant. Given a spoken query and a list of available options, pick the best match. Reply with only the option name, no explanation."},
-		{Role: "user", Content: prompt},
+	messages, err := s.matchMessages(query, options)
+	if err != nil {
+		log.Printf("failed to build LLM match prompt, using raw query: %v", err)
+		return query, nil, BranchPassthrough, err
 	}
 
-	result, err := s.llm.ChatCompletion(ctx, messages)
+	result, err := s.chatCompletion(ctx, llm, messages)
 	if err != nil {
+		err = fmt.Errorf("match with LLM: %w", err)
+		s.logger.Warn("LLM match failed", "query", query, "error", err)
+		if ms.local && ctx.Err() == nil {
+			log.Printf("LLM matching failed, matching locally: %v", err)
+			matched, branch := s.matchLocal(query, index)
+			return matched, nil, branch, err
+		}
 		log.Printf("LLM matching failed, using raw query: %v", err)
-		return query
+		return query, nil, BranchPassthrough, err
 	}
 
 	result = strings.TrimSpace(result)
 	if result == "" {
-		return query
+		return query, nil, BranchPassthrough, nil
+	}
+
+	// Only trust replies that name actual options; models sometimes invent titles.
+	var candidates []string
+	if s.rankedMatching {
+		candidates = rankedReplyOptions(index, result)
+	} else if option, ok := replyOption(index, result); ok {
+		candidates = []string{option.Name}
+	}
+	if len(candidates) == 0 {
+		log.Printf("LLM reply %q for %q is not an available option, using raw query", result, query)
+		s.logger.Debug("LLM reply is not an option", "query", query, "reply", result)
+		return query, nil, BranchPassthrough, nil
+	}
+
+	matched := candidates[0]
+	log.Printf("LLM matched %q -> %q", query, matched)
+	s.logger.Debug("LLM matched play option", "query", query, "option", matched)
+	if !s.rankedMatching {
+		return matched, nil, BranchLLM, nil
+	}
+	return matched, candidates, BranchLLM, nil
+}
+
+// replyOption returns the option a single-answer LLM reply names, trying the
+// reply as given and then with its decoration stripped.
+func replyOption(index *optionIndex, reply string) (bot.PlayOption, bool) {
+	if option, ok := index.find(reply); ok {
+		return option, true
+	}
+	return index.find(cleanLLMReply(reply))
+}
+
+// cleanLLMReply strips decoration models commonly add around a bare answer:
+// markdown code fences, surrounding quotes or backticks, and trailing punctuation.
+func cleanLLMReply(reply string) string {
+	reply = strings.TrimSpace(reply)
+
+	// Drop a fence line such as "```text" when the answer is on the following line.
+	if strings.HasPrefix(reply, "```") {
+		if first, rest, ok := strings.Cut(reply, "\n"); ok && !strings.Contains(first[3:], "`") {
+			reply = rest
+		}
+	}
+
+	for {
+		prev := reply
+		reply = strings.TrimSpace(reply)
+		reply = strings.TrimRight(reply, ".!?,;:")
+		reply = strings.Trim(reply, "\"'`“”‘’")
+		if reply == prev {
+			return reply
+		}
+	}
+}
+
+// BuildMatchMessages returns the messages sent to the LLM to match a play query
+// against the given options: the system prompt followed by the rendered match
+// prompt. It is what play commands use, exposed for debugging prompts and for
+// reusing them elsewhere. It fails only if a custom match prompt cannot be
+// rendered.
+func (s *VoiceService) BuildMatchMessages(query string, options []bot.PlayOption) ([]bot.LLMMessage, error) {
+	return buildMessages(s.matchPrompt, matchSystemPrompt, query, options)
+}
+
+// buildMessages renders a match prompt template for query and options and
+// pairs it with the system prompt.
+func buildMessages(tmpl *template.Template, system, query string, options []bot.PlayOption) ([]bot.LLMMessage, error) {
+	optionNames := make([]string, 0, len(options))
+	for _, opt := range options {
+		optionNames = append(optionNames, opt.Name)
+	}
+
+	var prompt strings.Builder
+	err := tmpl.Execute(&prompt, MatchPromptData{
+		Query:       query,
+		Options:     strings.Join(optionNames, "\n"),
+		OptionNames: optionNames,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("render match prompt: %w", err)
 	}
 
-	log.Printf("LLM matched %q -> %q", query, result)
-	return result
+	return []bot.LLMMessage{
+		{Role: "system", Content: system},
+		{Role: "user", Content: prompt.String()},
+	}, nil
 }
@@ -1,7 +1,11 @@
 package application
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
@@ -12,18 +16,41 @@ import (
 type mockSTT struct {
 	text string
 	err  error
+
+	calls int
+	audio []byte // audio from the most recent call
 }
 
-func (m *mockSTT) Transcribe(_ context.Context, _ []byte) (string, error) {
+func (m *mockSTT) Transcribe(_ context.Context, audio []byte) (string, error) {
+	m.calls++
+	m.audio = audio
 	return m.text, m.err
 }
 
+// sequenceSTT returns the given transcriptions in order, one per call.
+type sequenceSTT struct {
+	texts []string
+	calls int
+}
+
+func (m *sequenceSTT) Transcribe(_ context.Context, _ []byte) (string, error) {
+	if m.calls >= len(m.texts) {
+		return "", nil
+	}
+	text := m.texts[m.calls]
+	m.calls++
+	return text, nil
+}
+
 type mockLLM struct {
 	reply string
 	err   error
+
+	messages []bot.LLMMessage // messages from the most recent call
 }
 
-func (m *mockLLM) ChatCompletion(_ context.Context, _ []bot.LLMMessage) (string, error) {
+func (m *mockLLM) ChatCompletion(_ context.Context, messages []bot.LLMMessage) (string, error) {
+	m.messages = messages
 	return m.reply, m.err
 }
 
@@ -101,6 +128,47 @@ func TestWakePhrase_AlternateSpellings(t *testing.T) {
 	}
 }
 
+func TestWakePhrase_CustomAlternates(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{}, "jarvis", nil, nil)
+	svc.SetWakeAlternates("Jarvis", []string{"jarvus", "Jervis"})
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"wake phrase", "jarvis stop", "!stop"},
+		{"first alternate", "jarvus stop", "!stop"},
+		{"second alternate", "Jervis stop", "!stop"},
+		{"alternate with filler", "hey jervis stop", "!stop"},
+		{"alternate with two fillers", "oh hey jarvus play random", "!pr"},
+		{"unrelated word", "jarvik stop", ""},
+		{"alternate as part of word", "jarvuses stop", ""},
+		{"default alternate for other phrase", "lazer stop", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWakePhrase_ReplaceDefaultAlternates(t *testing.T) {
+	svc := newTestService()
+	svc.SetWakeAlternates("laser", []string{"razor"})
+
+	if got := parse(t, svc, "razor stop"); got != "!stop" {
+		t.Errorf("parse(%q) = %q, want %q", "razor stop", got, "!stop")
+	}
+	if got := parse(t, svc, "lazer stop"); got != "" {
+		t.Errorf("parse(%q) = %q, want no match after replacing alternates", "lazer stop", got)
+	}
+}
+
 func TestWakePhrase_FillerWordsBefore(t *testing.T) {
 	svc := newTestService()
 
@@ -175,6 +243,89 @@ func TestWakePhrase_OnlyWakePhrase(t *testing.T) {
 	}
 }
 
+func TestWakePhrase_TokenAndFillerRecorded(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name       string
+		input      string
+		wantToken  string
+		wantFiller string
+	}{
+		{"filler and alternate", "oh hey lazer stop", "lazer", "oh hey"},
+		{"caps and punctuation", "Hey, LASER stop", "laser", "hey"},
+		{"no filler", "laser stop", "laser", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, ok := svc.parseCommand(context.Background(), tt.input)
+			if !ok {
+				t.Fatalf("parseCommand(%q) returned no match", tt.input)
+			}
+			if cmd.Text != "!stop" {
+				t.Errorf("Text = %q, want %q", cmd.Text, "!stop")
+			}
+			if cmd.WakeToken != tt.wantToken {
+				t.Errorf("WakeToken = %q, want %q", cmd.WakeToken, tt.wantToken)
+			}
+			if cmd.FillerPrefix != tt.wantFiller {
+				t.Errorf("FillerPrefix = %q, want %q", cmd.FillerPrefix, tt.wantFiller)
+			}
+		})
+	}
+}
+
+func TestWakePhrase_TokenInExplain(t *testing.T) {
+	svc := newTestService()
+
+	trace, ok := svc.Explain(context.Background(), "oh hey lazer stop and play random")
+	if !ok {
+		t.Fatal("Explain returned no match")
+	}
+	if trace.Command.WakeToken != "lazer" || trace.Command.FillerPrefix != "oh hey" {
+		t.Errorf("Explain command wake = %q/%q, want %q/%q",
+			trace.Command.WakeToken, trace.Command.FillerPrefix, "lazer", "oh hey")
+	}
+	for _, cmd := range svc.ParseCommands(context.Background(), "oh hey lazer stop and play random") {
+		if cmd.WakeToken != "lazer" || cmd.FillerPrefix != "oh hey" {
+			t.Errorf("ParseCommands %q wake = %q/%q, want %q/%q",
+				cmd.Text, cmd.WakeToken, cmd.FillerPrefix, "lazer", "oh hey")
+		}
+	}
+}
+
+func TestWakePhrase_NotRequired(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantRequired string
+		wantOptional string
+	}{
+		{"bare stop", "stop", "", "!stop"},
+		{"bare play", "play never gonna give you up", "", "!play never gonna give you up"},
+		{"bare play random", "play random", "", "!pr"},
+		{"wake phrase still accepted", "laser stop", "!stop", "!stop"},
+		{"filler and wake phrase", "hey laser play random", "!pr", "!pr"},
+		{"not a command", "hello there", "", ""},
+	}
+
+	required := newTestService()
+	optional := newTestService()
+	optional.SetRequireWakePhrase(false)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, required, tt.input); got != tt.wantRequired {
+				t.Errorf("wake required: parse(%q) = %q, want %q", tt.input, got, tt.wantRequired)
+			}
+			if got := parse(t, optional, tt.input); got != tt.wantOptional {
+				t.Errorf("wake optional: parse(%q) = %q, want %q", tt.input, got, tt.wantOptional)
+			}
+		})
+	}
+}
+
 // --- Stop command ---
 
 func TestStopCommand(t *testing.T) {
@@ -207,9 +358,7 @@ func TestStopCommand(t *testing.T) {
 	}
 }
 
-// --- Play random ---
-
-func TestPlayRandom(t *testing.T) {
+func TestPunctuationTolerance(t *testing.T) {
 	svc := newTestService()
 
 	tests := []struct {
@@ -217,13 +366,19 @@ func TestPlayRandom(t *testing.T) {
 		input string
 		want  string
 	}{
-		{"basic", "laser play random", "!pr"},
-		{"capitalized", "laser play Random", "!pr"},
-		{"all caps", "laser play RANDOM", "!pr"},
-		{"something random", "laser play something random", "!pr"},
-		{"random song", "laser play a random song", "!pr"},
-		{"with filler", "hey laser play random", "!pr"},
-		{"alternate spelling", "lazer play random", "!pr"},
+		{"comma after wake phrase", "laser, stop", "!stop"},
+		{"comma and period", "Laser, stop.", "!stop"},
+		{"period after wake phrase", "laser. play, random", "!pr"},
+		{"no space after comma", "laser,stop", "!stop"},
+		{"commas around filler", "hey, laser, skip", "!skip"},
+		{"comma before keyword phrase", "laser, next, song", "!skip"},
+		{"comma inside keyword phrase", "laser, clear, the queue.", "!clear"},
+		{"lone punctuation", "laser - stop", "!stop"},
+		{"wake phrase with exclamation", "Laser! Leave.", "!leave"},
+		{"comma in play query", "laser, play never, gonna give you up.", "!play never gonna give you up"},
+		{"digit grouping kept", "laser play 10,000 maniacs", "!play 10,000 maniacs"},
+		{"comma before conjunction", "laser, stop, and play random", "!stop"},
+		{"alternate spelling", "Lazer, pause.", "!pause"},
 	}
 
 	for _, tt := range tests {
@@ -236,9 +391,9 @@ func TestPlayRandom(t *testing.T) {
 	}
 }
 
-// --- Play command (no LLM, passthrough) ---
+// --- Cancel command ---
 
-func TestPlayCommand_Passthrough(t *testing.T) {
+func TestCancelCommand(t *testing.T) {
 	svc := newTestService()
 
 	tests := []struct {
@@ -246,9 +401,14 @@ func TestPlayCommand_Passthrough(t *testing.T) {
 		input string
 		want  string
 	}{
-		{"basic query", "laser play never gonna give you up", "!play never gonna give you up"},
-		{"with filler", "hey laser play some song", "!play some song"},
-		{"alternate spelling", "lazer play test", "!play test"},
+		{"cancel", "laser cancel", "!cancel"},
+		{"never mind", "laser never mind", "!cancel"},
+		{"nevermind one word", "laser nevermind", "!cancel"},
+		{"forget it", "laser forget it", "!cancel"},
+		{"stop that", "laser stop that", "!cancel"},
+		{"with punctuation", "laser never mind.", "!cancel"},
+		{"caps with filler", "Hey Laser Forget It!", "!cancel"},
+		{"alternate spelling", "lazer cancel that", "!cancel"},
 	}
 
 	for _, tt := range tests {
@@ -261,97 +421,246 @@ func TestPlayCommand_Passthrough(t *testing.T) {
 	}
 }
 
-func TestPlayCommand_EmptyQuery(t *testing.T) {
+func TestCancelCommand_StopPrecedence(t *testing.T) {
 	svc := newTestService()
 
-	got := parse(t, svc, "laser play")
-	if got != "" {
-		t.Errorf("parse(%q) = %q, want no match (empty play query)", "laser play", got)
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"stop that cancels", "laser stop that", "!cancel"},
+		{"stop that now cancels", "laser stop that now", "!cancel"},
+		{"plain stop still stops", "laser stop", "!stop"},
+		{"stop the music stops", "laser stop the music", "!stop"},
+		{"stop thats not a whole word", "laser stop thats enough", "!stop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
 	}
 }
 
-// --- Play command with LLM matching ---
+// --- Mute / unmute ---
 
-func TestPlayCommand_LLMMatching(t *testing.T) {
-	llm := &mockLLM{reply: "itsworking"}
-	opts := &mockPlayOptions{options: []bot.PlayOption{
-		{Name: "itsworking"},
-		{Name: "miragewish"},
-	}}
-	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+func TestMuteCommands(t *testing.T) {
+	svc := newTestService()
 
-	got := parse(t, svc, "laser play its working")
-	if got != "!play itsworking" {
-		t.Errorf("parse with LLM = %q, want %q", got, "!play itsworking")
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"mute", "laser mute", "!mute"},
+		{"unmute", "laser unmute", "!unmute"},
+		{"unmute split by stt", "laser un mute", "!unmute"},
+		{"mute trailing words", "laser mute the music", "!mute"},
+		{"unmute trailing words", "laser unmute it please", "!unmute"},
+		{"caps", "laser MUTE", "!mute"},
+		{"punctuation", "laser mute!", "!mute"},
+		{"filler prefix", "hey laser mute", "!mute"},
+		{"filler and alternate", "yo lazer unmute.", "!unmute"},
+		{"not a whole word", "laser muted", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
 	}
 }
 
-func TestPlayCommand_LLMFallback_NoOptions(t *testing.T) {
-	llm := &mockLLM{}
-	opts := &mockPlayOptions{options: []bot.PlayOption{}}
-	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+// --- Previous ---
 
-	got := parse(t, svc, "laser play some song")
-	if got != "!play some song" {
-		t.Errorf("parse with empty options = %q, want %q", got, "!play some song")
+func TestPreviousCommand(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"play previous", "laser play previous", "!previous"},
+		{"go back", "laser go back", "!previous"},
+		{"previous song", "laser previous song", "!previous"},
+		{"play the previous one", "laser play the previous one", "!previous"},
+		{"caps", "LASER PLAY PREVIOUS!", "!previous"},
+		{"filler prefix", "hey laser go back", "!previous"},
+		{"alternate spelling", "lazer previous", "!previous"},
+		{"not a whole word", "laser play previously unreleased", "!play previously unreleased"},
+		{"no collision with play query", "laser play previous", "!previous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
 	}
 }
 
-// --- HandleVoice integration ---
+func TestLeaveCommand(t *testing.T) {
+	svc := newTestService()
 
-func TestHandleVoice_TranscribesAndParses(t *testing.T) {
-	stt := &mockSTT{text: "hey laser stop"}
-	svc := NewVoiceService(stt, "laser", nil, nil)
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"leave", "laser leave", "!leave"},
+		{"disconnect", "laser disconnect", "!leave"},
+		{"get out", "laser get out", "!leave"},
+		{"caps", "LASER DISCONNECT!", "!leave"},
+		{"filler prefix", "hey laser leave", "!leave"},
+		{"command filler", "laser um get out", "!leave"},
+		{"alternate spelling", "lazer leave", "!leave"},
+		{"not a whole word", "laser leaves", ""},
+		{"no collision with play query", "laser play leave the night on", "!play leave the night on"},
+		{"play query with get out", "laser play get out of my head", "!play get out of my head"},
+	}
 
-	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
-	if err != nil {
-		t.Fatalf("HandleVoice error: %v", err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
 	}
-	if got != "!stop" {
-		t.Errorf("HandleVoice = %q, want %q", got, "!stop")
+}
+
+func TestLeaveCommand_StopAndLeave(t *testing.T) {
+	svc := newTestService()
+
+	for _, input := range []string{"laser stop and leave", "laser stop then disconnect", "laser stop and get out"} {
+		cmds := svc.ParseCommands(context.Background(), input)
+		if len(cmds) != 2 || cmds[0].Text != "!stop" || cmds[1].Text != "!leave" {
+			t.Errorf("ParseCommands(%q) = %+v, want !stop then !leave", input, cmds)
+		}
 	}
 }
 
-func TestHandleVoice_EmptyTranscription(t *testing.T) {
-	stt := &mockSTT{text: ""}
-	svc := NewVoiceService(stt, "laser", nil, nil)
+func TestSaveCommand(t *testing.T) {
+	svc := newTestService()
 
-	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
-	if err != nil {
-		t.Fatalf("HandleVoice error: %v", err)
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"save this", "laser save this", "!save"},
+		{"save this song", "laser save this song", "!save"},
+		{"favorite this song", "laser favorite this song", "!save"},
+		{"british spelling", "laser favourite this", "!save"},
+		{"like this", "laser like this", "!save"},
+		{"trailing words", "laser like this song a lot", "!save"},
+		{"caps and punctuation", "LASER SAVE THIS!", "!save"},
+		{"command filler", "laser um save that", "!save"},
+		{"filler prefix", "hey laser like this", "!save"},
+		{"bare save", "laser save", ""},
+		{"play query", "laser play save tonight", "!play save tonight"},
 	}
-	if got != "" {
-		t.Errorf("HandleVoice = %q, want empty", got)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
 	}
 }
 
-func TestHandleVoice_NoWakePhrase(t *testing.T) {
-	stt := &mockSTT{text: "hello there"}
-	svc := NewVoiceService(stt, "laser", nil, nil)
+// --- Now playing ---
 
-	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
-	if err != nil {
-		t.Fatalf("HandleVoice error: %v", err)
+func TestNowPlayingCommand(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"contraction", "laser what's playing", "!np"},
+		{"curly apostrophe", "laser what’s playing", "!np"},
+		{"no apostrophe", "laser whats playing", "!np"},
+		{"spelled out", "laser what is playing", "!np"},
+		{"now playing", "laser now playing", "!np"},
+		{"question mark", "laser what's playing?", "!np"},
+		{"trailing words", "laser what's playing right now", "!np"},
+		{"filler prefix", "hey laser what's playing", "!np"},
+		{"caps", "LASER WHAT'S PLAYING", "!np"},
+		{"alternate spelling", "yo lazer now playing", "!np"},
 	}
-	if got != "" {
-		t.Errorf("HandleVoice = %q, want empty", got)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
 	}
 }
 
-// --- Custom wake phrase ---
+// --- Volume ---
 
-func TestCustomWakePhrase(t *testing.T) {
-	svc := NewVoiceService(&mockSTT{}, "jarvis", nil, nil)
+func TestVolumeCommand_LevelWordsSplitCompound(t *testing.T) {
+	svc := newTestService()
+
+	cmds := svc.ParseCommands(context.Background(), "laser unmute and full volume")
+	if len(cmds) != 2 || cmds[1].Text != "!volume 100" {
+		t.Errorf("ParseCommands = %+v, want !unmute then !volume 100", cmds)
+	}
+}
+
+func TestVolumeCommand(t *testing.T) {
+	svc := newTestService()
 
 	tests := []struct {
 		name  string
 		input string
 		want  string
 	}{
-		{"exact", "jarvis stop", "!stop"},
-		{"with filler", "hey jarvis stop", "!stop"},
-		{"caps", "JARVIS stop", "!stop"},
-		{"wrong phrase", "laser stop", ""},
+		{"digits", "laser volume 50", "!volume 50"},
+		{"spoken", "laser volume fifty", "!volume 50"},
+		{"spoken compound", "laser volume twenty five", "!volume 25"},
+		{"hyphenated", "laser volume twenty-five", "!volume 25"},
+		{"to", "laser volume to thirty", "!volume 30"},
+		{"set the volume", "hey laser set the volume to a hundred", "!volume 100"},
+		{"percent", "laser set volume at 40 percent", "!volume 40"},
+		{"zero", "laser volume zero", "!volume 0"},
+		{"caps", "LASER VOLUME TEN", "!volume 10"},
+		{"out of range", "laser volume 150", ""},
+		{"negative", "laser volume -5", "!volume -5"},
+		{"signed up", "laser volume +10", "!volume +10"},
+		{"signed level", "laser set the volume to -5", ""},
+		{"signed zero", "laser volume -0", ""},
+		{"no level", "laser volume", ""},
+		{"not a number", "laser volume loud", ""},
+		{"compound", "laser stop and volume 20", "!stop"},
+		{"max volume", "laser max volume", "!volume 100"},
+		{"maximum volume", "laser maximum volume", "!volume 100"},
+		{"full volume", "laser Full Volume!", "!volume 100"},
+		{"volume max", "laser volume max", "!volume 100"},
+		{"volume to max caps", "LASER VOLUME TO MAX", "!volume 100"},
+		{"set the volume to maximum", "laser set the volume to maximum", "!volume 100"},
+		{"min volume", "laser min volume", "!volume 0"},
+		{"minimum volume", "laser Minimum volume", "!volume 0"},
+		{"volume min", "laser volume min", "!volume 0"},
+		{"max without volume", "laser max", ""},
+		{"full without volume", "laser full", ""},
+		{"number still parsed", "laser volume to eighty", "!volume 80"},
 	}
 
 	for _, tt := range tests {
@@ -363,3 +672,1068 @@ func TestCustomWakePhrase(t *testing.T) {
 		})
 	}
 }
+
+func TestPlayCommand_NumbersNotNormalized(t *testing.T) {
+	svc := newTestService()
+
+	got := parse(t, svc, "laser play Seven Nation Army")
+	if got != "!play Seven Nation Army" {
+		t.Errorf("parse = %q, want %q", got, "!play Seven Nation Army")
+	}
+}
+
+// --- Play random ---
+
+func TestPlayRandom(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"basic", "laser play random", "!pr"},
+		{"capitalized", "laser play Random", "!pr"},
+		{"all caps", "laser play RANDOM", "!pr"},
+		{"something random", "laser play something random", "!pr"},
+		{"random song", "laser play a random song", "!pr"},
+		{"with filler", "hey laser play random", "!pr"},
+		{"alternate spelling", "lazer play random", "!pr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// --- Play command (no LLM, passthrough) ---
+
+func TestPlayCommand_Passthrough(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"basic query", "laser play never gonna give you up", "!play never gonna give you up"},
+		{"with filler", "hey laser play some song", "!play some song"},
+		{"alternate spelling", "lazer play test", "!play test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayCommand_PreservesQueryCasing(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"artist name", "laser play Daft Punk", "!play Daft Punk"},
+		{"caps keyword", "LASER PLAY Daft Punk", "!play Daft Punk"},
+		{"mixed case keyword", "Hey Laser Play AC DC", "!play AC DC"},
+		{"punctuation stripped", "laser play Daft Punk!", "!play Daft Punk"},
+		{"all caps query", "laser play ABBA", "!play ABBA"},
+		{"random still case-insensitive", "laser play RANDOM", "!pr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaylistCommand(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"named", "laser play my chill playlist", "!playlist chill"},
+		{"multi-word name", "laser play my Friday Night playlist", "!playlist Friday Night"},
+		{"politeness", "hey laser play my chill playlist please", "!playlist chill"},
+		{"random in name", "laser play my random playlist", "!playlist random"},
+		{"bare", "laser play my playlist", ""},
+		{"playlist mid-query", "laser play the playlist song", "!play the playlist song"},
+		{"playlist at the end without my", "laser play a chill playlist", "!play a chill playlist"},
+		{"my without playlist", "laser play my heart will go on", "!play my heart will go on"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaylistCommand_SkipsLLM(t *testing.T) {
+	llm := &mockLLM{reply: "itsworking"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	if got := parse(t, svc, "laser play my chill playlist"); got != "!playlist chill" {
+		t.Errorf("parse = %q, want %q", got, "!playlist chill")
+	}
+	if llm.messages != nil {
+		t.Error("LLM was consulted for a playlist command")
+	}
+}
+
+func TestPlayCommand_EmptyQuery(t *testing.T) {
+	svc := newTestService()
+
+	got := parse(t, svc, "laser play")
+	if got != "" {
+		t.Errorf("parse(%q) = %q, want no match (empty play query)", "laser play", got)
+	}
+}
+
+// --- Play command with LLM matching ---
+
+func TestPlayCommand_LLMMatching(t *testing.T) {
+	llm := &mockLLM{reply: "itsworking"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "miragewish"},
+	}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	got := parse(t, svc, "laser play its working")
+	if got != "!play itsworking" {
+		t.Errorf("parse with LLM = %q, want %q", got, "!play itsworking")
+	}
+}
+
+func TestPlayCommand_LLMReplyNormalizedToOption(t *testing.T) {
+	llm := &mockLLM{reply: "  Mirage   Wish "}
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "mirage wish"},
+	}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	got := parse(t, svc, "laser play mirage fish")
+	if got != "!play mirage wish" {
+		t.Errorf("parse with LLM = %q, want %q", got, "!play mirage wish")
+	}
+}
+
+func TestPlayCommand_LLMDecoratedReplies(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "miragewish"},
+	}}
+
+	tests := []struct {
+		name  string
+		reply string
+	}{
+		{"double quotes and period", `"itsworking".`},
+		{"single quotes", `'itsworking'`},
+		{"smart quotes", "“itsworking”"},
+		{"trailing punctuation", "itsworking!"},
+		{"surrounding whitespace", "\n  itsworking \t"},
+		{"inline backticks", "`itsworking`"},
+		{"code fence", "```\nitsworking\n```"},
+		{"code fence with language", "```text\nitsworking\n```"},
+		{"quoted inside backticks", "`\"itsworking\"`."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: tt.reply}, opts)
+			got := parse(t, svc, "laser play its working")
+			if got != "!play itsworking" {
+				t.Errorf("parse with reply %q = %q, want %q", tt.reply, got, "!play itsworking")
+			}
+		})
+	}
+}
+
+func TestPlayCommand_LLMReplyKeepsOptionPunctuation(t *testing.T) {
+	llm := &mockLLM{reply: "Help!"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "Help!"},
+		{Name: "Help"},
+	}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	got := parse(t, svc, "laser play help")
+	if got != "!play Help!" {
+		t.Errorf("parse = %q, want %q", got, "!play Help!")
+	}
+}
+
+func TestPlayCommand_LLMHallucinationFallsBack(t *testing.T) {
+	llm := &mockLLM{reply: "never gonna give you up"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "miragewish"},
+	}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	trace, ok := svc.Explain(context.Background(), "laser play rick roll")
+	if !ok {
+		t.Fatal("Explain returned no match")
+	}
+	if trace.Command.Text != "!play rick roll" {
+		t.Errorf("parse with hallucinated reply = %q, want %q", trace.Command.Text, "!play rick roll")
+	}
+	if trace.Branch != BranchPassthrough {
+		t.Errorf("Branch = %q, want %q", trace.Branch, BranchPassthrough)
+	}
+}
+
+func TestPlayCommand_LLMFallback_NoOptions(t *testing.T) {
+	llm := &mockLLM{}
+	opts := &mockPlayOptions{options: []bot.PlayOption{}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	got := parse(t, svc, "laser play some song")
+	if got != "!play some song" {
+		t.Errorf("parse with empty options = %q, want %q", got, "!play some song")
+	}
+}
+
+func TestPlayCommand_OptionsErrorFallsBack(t *testing.T) {
+	optsErr := errors.New("options API down")
+	llm := &mockLLM{reply: "itsworking"}
+	stt := &mockSTT{text: "laser play Its Working"}
+	svc := NewVoiceService(stt, "laser", llm, &mockPlayOptions{err: optsErr})
+
+	trace, ok := svc.Explain(context.Background(), "laser play Its Working")
+	if !ok || trace.Command.Text != "!play Its Working" {
+		t.Fatalf("Explain = (%q, %v), want passthrough %q", trace.Command.Text, ok, "!play Its Working")
+	}
+	if trace.Branch != BranchPassthrough {
+		t.Errorf("Branch = %q, want %q", trace.Branch, BranchPassthrough)
+	}
+	if !errors.Is(trace.MatchError, optsErr) {
+		t.Errorf("MatchError = %v, want %v", trace.MatchError, optsErr)
+	}
+	if llm.messages != nil {
+		t.Error("LLM was consulted without options")
+	}
+	if n := svc.Stats().MatchErrors; n != 0 {
+		t.Errorf("MatchErrors = %d after Explain, want 0", n)
+	}
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "!play Its Working" {
+		t.Errorf("HandleVoice = %q, want passthrough %q", got, "!play Its Working")
+	}
+	if n := svc.Stats().MatchErrors; n != 1 {
+		t.Errorf("MatchErrors = %d, want 1", n)
+	}
+}
+
+func TestPlayCommand_LLMErrorRecorded(t *testing.T) {
+	llmErr := errors.New("rate limited")
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{err: llmErr}, opts)
+
+	trace, ok := svc.Explain(context.Background(), "laser play its working")
+	if !ok || trace.Command.Text != "!play its working" {
+		t.Fatalf("Explain = (%q, %v), want passthrough", trace.Command.Text, ok)
+	}
+	if !errors.Is(trace.MatchError, llmErr) {
+		t.Errorf("MatchError = %v, want %v", trace.MatchError, llmErr)
+	}
+}
+
+func TestPlayCommand_DefaultMatchPrompt(t *testing.T) {
+	llm := &mockLLM{reply: "itsworking"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "miragewish"},
+	}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	parse(t, svc, "laser play its working")
+
+	if len(llm.messages) != 2 {
+		t.Fatalf("LLM received %d messages, want 2", len(llm.messages))
+	}
+	want := "The user said: \"its working\"\n\n" +
+		"Available options:\nitsworking\nmiragewish\n\n" +
+		"Which option best matches what the user asked for? " +
+		"Reply with ONLY the exact option name, nothing else. " +
+		"If nothing matches, reply with the user's original query exactly as given."
+	if got := llm.messages[1].Content; got != want {
+		t.Errorf("default prompt = %q, want %q", got, want)
+	}
+}
+
+func TestPlayCommand_CustomMatchPrompt(t *testing.T) {
+	llm := &mockLLM{reply: "itsworking"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "miragewish"},
+	}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+	if err := svc.SetMatchPrompt("Query: {{.Query}} | Options: {{join .OptionNames}}"); err == nil {
+		t.Fatal("SetMatchPrompt with undefined function should fail")
+	}
+	if err := svc.SetMatchPrompt("Query: {{.Query}} | Options: {{range .OptionNames}}[{{.}}]{{end}}"); err != nil {
+		t.Fatalf("SetMatchPrompt error: %v", err)
+	}
+
+	got := parse(t, svc, "laser play its working")
+	if got != "!play itsworking" {
+		t.Errorf("parse with custom prompt = %q, want %q", got, "!play itsworking")
+	}
+
+	if len(llm.messages) != 2 {
+		t.Fatalf("LLM received %d messages, want 2", len(llm.messages))
+	}
+	if llm.messages[0].Role != "system" || llm.messages[1].Role != "user" {
+		t.Errorf("message roles = %q, %q, want system, user", llm.messages[0].Role, llm.messages[1].Role)
+	}
+	want := "Query: its working | Options: [itsworking][miragewish]"
+	if got := llm.messages[1].Content; got != want {
+		t.Errorf("custom prompt = %q, want %q", got, want)
+	}
+}
+
+func TestBuildMatchMessages(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{}, nil)
+	options := []bot.PlayOption{{Name: "itsworking"}, {Name: "miragewish"}}
+
+	messages, err := svc.BuildMatchMessages("its working", options)
+	if err != nil {
+		t.Fatalf("BuildMatchMessages error: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("BuildMatchMessages returned %d messages, want 2", len(messages))
+	}
+	if messages[0].Role != "system" || messages[0].Content != matchSystemPrompt {
+		t.Errorf("first message = %+v, want the system prompt", messages[0])
+	}
+	if messages[1].Role != "user" {
+		t.Errorf("second message role = %q, want user", messages[1].Role)
+	}
+	for _, want := range []string{`"its working"`, "itsworking", "miragewish"} {
+		if !strings.Contains(messages[1].Content, want) {
+			t.Errorf("user message %q does not contain %q", messages[1].Content, want)
+		}
+	}
+
+	// Play commands send exactly these messages.
+	llm := &mockLLM{reply: "itsworking"}
+	svc = NewVoiceService(&mockSTT{}, "laser", llm, &mockPlayOptions{options: options})
+	parse(t, svc, "laser play its working")
+	if !slices.Equal(llm.messages, messages) {
+		t.Errorf("LLM received %+v, want %+v", llm.messages, messages)
+	}
+}
+
+// --- Command prefix ---
+
+func TestCommandPrefix_Custom(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: "itsworking"}, opts)
+	svc.SetCommandPrefix("/")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser stop", "/stop"},
+		{"laser play random", "/pr"},
+		{"laser play its working", "/play itsworking"},
+		{"laser never mind", "/cancel"},
+		{"laser mute", "/mute"},
+		{"laser previous", "/previous"},
+		{"laser now playing", "/np"},
+		{"laser clear queue", "/clear"},
+		{"laser volume fifty", "/volume 50"},
+	}
+
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCommandPrefix_Default(t *testing.T) {
+	svc := newTestService()
+
+	if got := parse(t, svc, "laser stop"); got != "!stop" {
+		t.Errorf("parse = %q, want %q", got, "!stop")
+	}
+}
+
+func TestCommandPrefixFor(t *testing.T) {
+	svc := newTestService()
+	svc.SetCommandPrefixFor("Leave", "/")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser stop", "!stop"},
+		{"laser leave", "/leave"},
+		{"laser disconnect", "/leave"},
+		{"laser skip", "!skip"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	cmds := svc.ParseCommands(context.Background(), "laser stop and leave")
+	if len(cmds) != 2 || cmds[0].Text != "!stop" || cmds[1].Text != "/leave" {
+		t.Errorf("ParseCommands = %+v, want !stop then /leave", cmds)
+	}
+
+	// The override survives a change of the global prefix.
+	svc.SetCommandPrefix("?")
+	if got := parse(t, svc, "laser leave"); got != "/leave" {
+		t.Errorf("after SetCommandPrefix, leave = %q, want %q", got, "/leave")
+	}
+	if got := parse(t, svc, "laser stop"); got != "?stop" {
+		t.Errorf("after SetCommandPrefix, stop = %q, want %q", got, "?stop")
+	}
+
+	svc.SetCommandPrefixFor("leave", "")
+	if got := parse(t, svc, "laser leave"); got != "?leave" {
+		t.Errorf("after clearing override, leave = %q, want %q", got, "?leave")
+	}
+}
+
+// --- Compound commands ---
+
+func TestParseCommands_Compound(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"stop and play random", "laser stop and play random", []string{"!stop", "!pr"}},
+		{"play then stop", "laser play never gonna give you up then stop", []string{"!play never gonna give you up", "!stop"}},
+		{"and then", "laser stop and then play random", []string{"!stop", "!pr"}},
+		{"three commands", "laser play random then stop and play some song", []string{"!pr", "!stop", "!play some song"}},
+		{"single command", "laser stop", []string{"!stop"}},
+		{"and inside play query", "laser play rock and roll", []string{"!play rock and roll"}},
+		{"then inside play query", "laser play now and then", []string{"!play now and then"}},
+		{"no wake phrase", "stop and play random", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmds := svc.ParseCommands(context.Background(), tt.input)
+			var got []string
+			for _, cmd := range cmds {
+				got = append(got, cmd.Text)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ParseCommands(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommands_SingleMethodReturnsFirst(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser stop and play random", "!stop"},
+		{"laser play some song then stop", "!play some song"},
+	}
+
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// --- HandleVoice integration ---
+
+func TestHandleVoice_TranscribesAndParses(t *testing.T) {
+	stt := &mockSTT{text: "hey laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "!stop" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!stop")
+	}
+}
+
+func TestHandleVoiceDetailed(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want VoiceResult
+	}{
+		{"matched", " hey laser stop ", VoiceResult{Transcription: "hey laser stop", Command: "!stop", Outcome: OutcomeMatched, Confirmation: "Stopping playback"}},
+		{"unmatched", "hello there", VoiceResult{Transcription: "hello there", Outcome: OutcomeNoWakePhrase}},
+		{"wake only", "laser", VoiceResult{Transcription: "laser", Outcome: OutcomeNoCommandAfterWake}},
+		{"unknown command", "laser sing a song", VoiceResult{Transcription: "laser sing a song", Outcome: OutcomeNoCommandAfterWake}},
+		{"confirm with nothing pending", "laser confirm", VoiceResult{Transcription: "laser confirm", Outcome: OutcomeNoCommandAfterWake}},
+		{"silence", "", VoiceResult{Outcome: OutcomeNoSpeech}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewVoiceService(&mockSTT{text: tt.text}, "laser", nil, nil)
+
+			got, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+			if err != nil {
+				t.Fatalf("HandleVoiceDetailed error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HandleVoiceDetailed(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleVoiceDetailed_Outcomes(t *testing.T) {
+	stt := &mockSTT{text: "laser clear the queue"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetConfirmCommands([]string{"clear"})
+	svc.SetMinAudioBytes(4)
+
+	got, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("abc"))
+	if err != nil || got.Outcome != OutcomeNoSpeech {
+		t.Errorf("short clip = %+v, %v; want outcome %q", got, err, OutcomeNoSpeech)
+	}
+	if stt.calls != 0 {
+		t.Errorf("STT called %d times for a short clip, want 0", stt.calls)
+	}
+
+	got, err = svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil || got.Outcome != OutcomeAwaitingConfirmation || got.Command != "" {
+		t.Errorf("held command = %+v, %v; want outcome %q", got, err, OutcomeAwaitingConfirmation)
+	}
+
+	stt.text = "laser confirm"
+	got, err = svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil || got.Outcome != OutcomeMatched || got.Command != "!clear" {
+		t.Errorf("confirmation = %+v, %v; want !clear with outcome %q", got, err, OutcomeMatched)
+	}
+
+	svc.SetRequireWakePhrase(false)
+	stt.text = "hello there"
+	got, err = svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil || got.Outcome != OutcomeNoCommandAfterWake {
+		t.Errorf("wake not required = %+v, %v; want outcome %q", got, err, OutcomeNoCommandAfterWake)
+	}
+
+	stt.err = errors.New("stt down")
+	got, err = svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err == nil || got.Outcome != "" {
+		t.Errorf("STT failure = %+v, %v; want an error and no outcome", got, err)
+	}
+}
+
+func TestHandleVoice_EmptyTranscription(t *testing.T) {
+	stt := &mockSTT{text: ""}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("HandleVoice = %q, want empty", got)
+	}
+}
+
+func TestHandleVoice_WhitespaceTranscription(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"spaces", "   ", ""},
+		{"tabs and newlines", "\n\t", ""},
+		{"padded command", " hey laser stop ", "!stop"},
+		{"newline padded command", "\n laser play random\t", "!pr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewVoiceService(&mockSTT{text: tt.text}, "laser", nil, nil)
+
+			got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+			if err != nil {
+				t.Fatalf("HandleVoice error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HandleVoice(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			if tt.want == "" && svc.Stats().Transcriptions != 0 {
+				t.Errorf("whitespace transcription %q was parsed, want early return", tt.text)
+			}
+		})
+	}
+}
+
+func TestHandleVoice_NoWakePhrase(t *testing.T) {
+	stt := &mockSTT{text: "hello there"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("HandleVoice = %q, want empty", got)
+	}
+}
+
+func TestHandleVoice_MinAudioBytes(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetMinAudioBytes(100)
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("click"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("HandleVoice = %q, want empty for short audio", got)
+	}
+	if stt.calls != 0 {
+		t.Errorf("Transcribe called %d times, want 0", stt.calls)
+	}
+
+	got, err = svc.HandleVoice(context.Background(), "ch1", "u1", make([]byte, 100))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "!stop" {
+		t.Errorf("HandleVoice = %q, want %q at the threshold", got, "!stop")
+	}
+}
+
+func TestHandleVoice_MinAudioBytesDefault(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("x"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "!stop" || stt.calls != 1 {
+		t.Errorf("HandleVoice = %q with %d Transcribe calls, want %q with 1", got, stt.calls, "!stop")
+	}
+}
+
+// upperPreprocessor uppercases audio bytes so tests can tell it ran.
+type upperPreprocessor struct {
+	err error
+}
+
+func (p *upperPreprocessor) Process(_ context.Context, audio []byte) ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return bytes.ToUpper(audio), nil
+}
+
+func TestHandleVoice_AudioPreprocessor(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetAudioPreprocessor(&upperPreprocessor{})
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if got != "!stop" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!stop")
+	}
+	if string(stt.audio) != "FAKE-AUDIO" {
+		t.Errorf("STT received %q, want processed %q", stt.audio, "FAKE-AUDIO")
+	}
+}
+
+func TestHandleVoice_AudioPreprocessorError(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	procErr := errors.New("bad frame")
+	svc.SetAudioPreprocessor(&upperPreprocessor{err: procErr})
+
+	_, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if !errors.Is(err, procErr) {
+		t.Errorf("HandleVoice error = %v, want %v", err, procErr)
+	}
+	if stt.calls != 0 {
+		t.Errorf("Transcribe called %d times after preprocessing failed, want 0", stt.calls)
+	}
+}
+
+func TestHandleVoice_NoPreprocessorPassesThrough(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	if _, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio")); err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+	if string(stt.audio) != "fake-audio" {
+		t.Errorf("STT received %q, want unchanged %q", stt.audio, "fake-audio")
+	}
+}
+
+// --- Stats ---
+
+func TestStats_CountsMatchesAndMisses(t *testing.T) {
+	stt := &sequenceSTT{texts: []string{
+		"laser stop",
+		"hello there",
+		"laser play random",
+		"hey laser stop",
+		"laser",
+		"laser play some song",
+		"",
+	}}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	for range stt.texts {
+		if _, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio")); err != nil {
+			t.Fatalf("HandleVoice error: %v", err)
+		}
+	}
+
+	stats := svc.Stats()
+	if stats.Transcriptions != 6 {
+		t.Errorf("Transcriptions = %d, want 6", stats.Transcriptions)
+	}
+	if stats.Matched != 4 {
+		t.Errorf("Matched = %d, want 4", stats.Matched)
+	}
+	if stats.NoMatch != 2 {
+		t.Errorf("NoMatch = %d, want 2", stats.NoMatch)
+	}
+	wantCommands := map[string]int64{"stop": 2, "pr": 1, "play": 1}
+	for name, want := range wantCommands {
+		if got := stats.Commands[name]; got != want {
+			t.Errorf("Commands[%q] = %d, want %d", name, got, want)
+		}
+	}
+	if len(stats.Commands) != len(wantCommands) {
+		t.Errorf("Commands = %v, want %v", stats.Commands, wantCommands)
+	}
+}
+
+func TestStats_SnapshotIsCopy(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{text: "laser stop"}, "laser", nil, nil)
+	if _, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio")); err != nil {
+		t.Fatalf("HandleVoice error: %v", err)
+	}
+
+	snapshot := svc.Stats()
+	snapshot.Commands["stop"] = 100
+
+	if got := svc.Stats().Commands["stop"]; got != 1 {
+		t.Errorf("Commands[stop] after modifying snapshot = %d, want 1", got)
+	}
+}
+
+func TestStats_ExplainDoesNotCount(t *testing.T) {
+	svc := newTestService()
+	svc.Explain(context.Background(), "laser stop")
+
+	if stats := svc.Stats(); stats.Transcriptions != 0 {
+		t.Errorf("Transcriptions after Explain = %d, want 0", stats.Transcriptions)
+	}
+}
+
+// --- Custom wake phrase ---
+
+func TestCustomWakePhrase(t *testing.T) {
+	svc := NewVoiceService(&mockSTT{}, "jarvis", nil, nil)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"exact", "jarvis stop", "!stop"},
+		{"with filler", "hey jarvis stop", "!stop"},
+		{"caps", "JARVIS stop", "!stop"},
+		{"wrong phrase", "laser stop", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// --- Explain (dry run) ---
+
+func TestExplain_Branches(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{
+		{Name: "itsworking"},
+		{Name: "miragewish"},
+	}}
+	withLLM := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: "itsworking"}, opts)
+	noLLM := newTestService()
+
+	tests := []struct {
+		name       string
+		svc        *VoiceService
+		input      string
+		wantText   string
+		wantBranch MatchBranch
+	}{
+		{"stop keyword", noLLM, "laser stop", "!stop", BranchKeyword},
+		{"play random keyword", noLLM, "laser play random", "!pr", BranchKeyword},
+		{"llm match", withLLM, "laser play its working", "!play itsworking", BranchLLM},
+		{"passthrough without llm", noLLM, "laser play its working", "!play its working", BranchPassthrough},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trace, ok := tt.svc.Explain(context.Background(), tt.input)
+			if !ok {
+				t.Fatalf("Explain(%q) returned no match", tt.input)
+			}
+			if trace.Command.Text != tt.wantText {
+				t.Errorf("Explain(%q).Command.Text = %q, want %q", tt.input, trace.Command.Text, tt.wantText)
+			}
+			if trace.Branch != tt.wantBranch {
+				t.Errorf("Explain(%q).Branch = %q, want %q", tt.input, trace.Branch, tt.wantBranch)
+			}
+		})
+	}
+}
+
+func TestExplain_Query(t *testing.T) {
+	svc := newTestService()
+
+	trace, ok := svc.Explain(context.Background(), "hey laser play its working")
+	if !ok {
+		t.Fatal("Explain returned no match")
+	}
+	if trace.Query != "its working" {
+		t.Errorf("Query = %q, want %q", trace.Query, "its working")
+	}
+	if trace.Remainder != "play its working" {
+		t.Errorf("Remainder = %q, want %q", trace.Remainder, "play its working")
+	}
+}
+
+func TestParseBatch(t *testing.T) {
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", &mockLLM{reply: "itsworking"}, opts)
+
+	corpus := []string{
+		"laser stop",
+		"hello there",
+		"laser play its working",
+		"hey lazer play random",
+		"",
+		"laser play something else",
+	}
+	want := []ParseResult{
+		{Input: "laser stop", Command: "!stop", Branch: BranchKeyword},
+		{Input: "hello there"},
+		{Input: "laser play its working", Command: "!play itsworking", Branch: BranchLLM},
+		{Input: "hey lazer play random", Command: "!pr", Branch: BranchKeyword},
+		{Input: ""},
+		{Input: "laser play something else", Command: "!play itsworking", Branch: BranchLLM},
+	}
+
+	got := svc.ParseBatch(context.Background(), corpus)
+	if len(got) != len(want) {
+		t.Fatalf("ParseBatch returned %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if stats := svc.Stats(); stats.Transcriptions != 0 {
+		t.Errorf("ParseBatch recorded %d transcriptions, want 0", stats.Transcriptions)
+	}
+}
+
+func TestExplain_NoMatch(t *testing.T) {
+	svc := newTestService()
+
+	if trace, ok := svc.Explain(context.Background(), "hello there"); ok {
+		t.Errorf("Explain(%q) = %+v, want no match", "hello there", trace)
+	}
+}
+
+// --- Wake phrase validation ---
+
+func TestValidateWakePhrase(t *testing.T) {
+	tests := []struct {
+		name    string
+		phrase  string
+		wantErr error
+	}{
+		{"empty", "", ErrEmptyWakePhrase},
+		{"spaces", "   ", ErrEmptyWakePhrase},
+		{"tabs and newlines", "\t\n", ErrEmptyWakePhrase},
+		{"valid", "laser", nil},
+		{"valid with surrounding space", " jarvis ", nil},
+		{"only punctuation", "***", ErrInvalidWakePhrase},
+		{"only brackets", "( )", ErrInvalidWakePhrase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateWakePhrase(tt.phrase); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateWakePhrase(%q) = %v, want %v", tt.phrase, err, tt.wantErr)
+			}
+
+			svc, err := NewVoiceServiceChecked(&mockSTT{}, tt.phrase, nil, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewVoiceServiceChecked(%q) error = %v, want %v", tt.phrase, err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if svc != nil {
+					t.Errorf("NewVoiceServiceChecked(%q) returned a service alongside an error", tt.phrase)
+				}
+				return
+			}
+			want := strings.TrimSpace(tt.phrase) + " stop"
+			if got := parse(t, svc, want); got != "!stop" {
+				t.Errorf("parse(%q) = %q, want %q", want, got, "!stop")
+			}
+		})
+	}
+}
+
+func TestWakePhrase_SpecialCharacters(t *testing.T) {
+	tests := []struct {
+		phrase string
+		input  string
+		want   string
+	}{
+		// Inner punctuation is matched literally, never as a pattern.
+		{"la.ser", "la.ser stop", "!stop"},
+		{"la.ser", "laser stop", ""},
+		{"la.ser", "laxser stop", ""},
+		// Surrounding symbols are trimmed like they are from spoken words.
+		{"laser*", "laser stop", "!stop"},
+		{"laser*", "laserrr stop", ""},
+		{"(laser)", "laser stop", "!stop"},
+		{"(laser)", "Laser, stop.", "!stop"},
+		{"[a-z]+", "laser stop", ""},
+	}
+	for _, tt := range tests {
+		stt := &mockSTT{}
+		svc, err := NewVoiceServiceChecked(stt, tt.phrase, nil, nil)
+		if err != nil {
+			t.Fatalf("NewVoiceServiceChecked(%q) error: %v", tt.phrase, err)
+		}
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("wake %q: parse(%q) = %q, want %q", tt.phrase, tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWakePhrase_NeverPanics(t *testing.T) {
+	phrases := []string{
+		"la.ser", "laser*", "(laser)", "[", "\\", "$", "^laser$", "a|b", "(?i)laser",
+		"***", "\x00", "las\x00er", "\xff\xfe", "λέιζερ", "laser\nstop", "?", "{2,}",
+	}
+	for _, phrase := range phrases {
+		svc := NewVoiceService(&mockSTT{}, phrase, nil, nil)
+		svc.SetWakeAlternates(phrase, []string{phrase + "+", "(" + phrase})
+		_ = svc.SetChannelWakePhrase("ch1", phrase)
+		_ = svc.SetWakePhrase(phrase)
+		for _, input := range []string{phrase + " stop", "hey " + phrase, phrase, "laser stop"} {
+			parse(t, svc, input)
+			_, _ = svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte(input))
+		}
+	}
+}
+
+func TestSearchCommand(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"query", "laser search lo-fi beats", "!search lo-fi beats"},
+		{"casing preserved", "laser search Daft Punk", "!search Daft Punk"},
+		{"politeness", "hey laser search lo-fi beats please", "!search lo-fi beats"},
+		{"trailing punctuation", "laser search lo-fi beats.", "!search lo-fi beats"},
+		{"random is a query", "laser search random", "!search random"},
+		{"bare", "laser search", ""},
+		{"bare with punctuation", "laser search?", ""},
+		{"compound", "laser stop and search jazz", "!stop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parse(t, svc, tt.input)
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchCommand_SkipsLLM(t *testing.T) {
+	llm := &mockLLM{reply: "itsworking"}
+	opts := &mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}
+	svc := NewVoiceService(&mockSTT{}, "laser", llm, opts)
+
+	trace, ok := svc.Explain(context.Background(), "laser search its working")
+	if !ok || trace.Command.Text != "!search its working" {
+		t.Fatalf("Explain = (%q, %v), want %q", trace.Command.Text, ok, "!search its working")
+	}
+	if trace.Branch != BranchPassthrough {
+		t.Errorf("Branch = %q, want %q", trace.Branch, BranchPassthrough)
+	}
+	if llm.messages != nil {
+		t.Error("LLM was consulted for a search")
+	}
+	if cmds := svc.ParseCommands(context.Background(), "laser stop and search jazz"); len(cmds) != 2 || cmds[1].Text != "!search jazz" {
+		t.Errorf("ParseCommands = %+v, want !stop then !search jazz", cmds)
+	}
+}
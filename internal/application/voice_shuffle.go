@@ -0,0 +1,67 @@
+package application
+
+import (
+	"slices"
+	"strconv"
+)
+
+// shuffleCommand toggles shuffled playback of the queue.
+const shuffleCommand = "shuffle"
+
+// shuffleFlag is appended to play and playlist commands spoken with a shuffle
+// modifier, e.g. "play jazz on shuffle" → "!play jazz --shuffle".
+const shuffleFlag = "--shuffle"
+
+// shuffleModifiers are the phrases that ask for shuffled playback anywhere in a
+// play command. Longer phrases come first so "on shuffle" is removed whole.
+var shuffleModifiers = splitPhrases([]string{"in shuffle mode", "on shuffle", "with shuffle", "shuffled", "shuffle"})
+
+// leadingShuffle strips a "shuffle" that comes before a play verb, as in
+// "shuffle play jazz".
+func leadingShuffle(cw commandWords) (commandWords, bool) {
+	if len(cw.words) < 2 || cw.words[0] != "shuffle" {
+		return cw, false
+	}
+	if _, ok := playVerbLength(cw.words[1:]); !ok {
+		return cw, false
+	}
+	return cw.slice(1, len(cw.words)), true
+}
+
+// stripShuffle removes shuffle modifiers from the words of a play query and
+// reports whether there were any.
+func stripShuffle(cw commandWords) (commandWords, bool) {
+	var kept commandWords
+	found := false
+	for i := 0; i < len(cw.words); {
+		if n := modifierLength(cw.words[i:]); n > 0 {
+			found = true
+			i += n
+			continue
+		}
+		kept.words = append(kept.words, cw.words[i])
+		kept.spoken = append(kept.spoken, cw.spoken[i])
+		i++
+	}
+	if !found {
+		return cw, false
+	}
+	return kept, true
+}
+
+// modifierLength returns how many of the leading words form a shuffle
+// modifier, or 0 if they don't start with one.
+func modifierLength(words []string) int {
+	for _, phrase := range shuffleModifiers {
+		if len(words) >= len(phrase) && slices.Equal(words[:len(phrase)], phrase) {
+			return len(phrase)
+		}
+	}
+	return 0
+}
+
+// playCommand builds a play or playlist command, flagging it for shuffled
+// playback if asked.
+func (s *VoiceService) playCommand(name, query string, shuffle bool) VoiceCommand {
+	return s.commandWith(name, map[string]string{"query": query, "shuffle": strconv.FormatBool(shuffle)})
+}
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestShuffleModifier(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"after play", "laser play shuffle my liked songs", "!play my liked songs --shuffle"},
+		{"before play", "laser shuffle play jazz", "!play jazz --shuffle"},
+		{"trailing", "laser play jazz on shuffle", "!play jazz --shuffle"},
+		{"trailing with politeness", "laser play jazz on shuffle please", "!play jazz --shuffle"},
+		{"shuffled", "laser play Daft Punk shuffled", "!play Daft Punk --shuffle"},
+		{"shuffle mode", "laser play jazz in shuffle mode", "!play jazz --shuffle"},
+		{"play verb synonym", "laser shuffle put on some jazz", "!play some jazz --shuffle"},
+		{"playlist", "laser play my chill playlist on shuffle", "!playlist chill --shuffle"},
+		{"random ignores shuffle", "laser shuffle play something random", "!pr"},
+		{"no modifier", "laser play jazz", "!play jazz"},
+		{"not a whole word", "laser play shuffleboard music", "!play shuffleboard music"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShuffleModifier_QueryStripped(t *testing.T) {
+	svc := newTestService()
+
+	trace, ok := svc.Explain(context.Background(), "laser shuffle play jazz")
+	if !ok || trace.Query != "jazz" {
+		t.Errorf("Explain query = %q (ok=%v), want %q", trace.Query, ok, "jazz")
+	}
+}
+
+func TestShuffleCommand(t *testing.T) {
+	svc := newTestService()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser shuffle", "!shuffle"},
+		{"hey laser shuffle", "!shuffle"},
+		{"laser um shuffle", "!shuffle"},
+		{"laser play shuffle", "!shuffle"},
+		{"laser play on shuffle", "!shuffle"},
+		{"laser shuffle the queue", "!shuffle"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
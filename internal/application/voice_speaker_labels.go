@@ -0,0 +1,38 @@
+package application
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SetSpeakerLabels strips speaker labels that some STT providers put at the
+// start of each line (e.g. "Speaker 1:") before looking for the wake phrase.
+// Each pattern is a regexp matched at the start of a line; whitespace around
+// the label is removed with it. Passing no patterns turns stripping off.
+//
+// Example: (?i)speaker \d+:
+func (s *VoiceService) SetSpeakerLabels(patterns []string) error {
+	if len(patterns) == 0 {
+		s.speakerLabels = nil
+		return nil
+	}
+	alts := make([]string, len(patterns))
+	for i, p := range patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return fmt.Errorf("speaker label pattern %q: %w", p, err)
+		}
+		alts[i] = "(?:" + p + ")"
+	}
+	s.speakerLabels = regexp.MustCompile(`(?m)^[ \t]*(?:` + strings.Join(alts, "|") + `)[ \t]*`)
+	return nil
+}
+
+// stripSpeakerLabels removes the configured speaker labels from the start of
+// each line of the transcription.
+func (s *VoiceService) stripSpeakerLabels(transcription string) string {
+	if s.speakerLabels == nil {
+		return transcription
+	}
+	return s.speakerLabels.ReplaceAllString(transcription, "")
+}
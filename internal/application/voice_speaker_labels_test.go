@@ -0,0 +1,67 @@
+package application
+
+import "testing"
+
+func TestSpeakerLabels(t *testing.T) {
+	svc := newTestService()
+	if err := svc.SetSpeakerLabels([]string{`(?i)speaker \d+( \(\d+:\d+\))?:`, `\[[A-Z_0-9]+\]`}); err != nil {
+		t.Fatalf("SetSpeakerLabels error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"speaker label", "Speaker 1: laser stop", "!stop"},
+		{"label with timestamp", "Speaker 1 (0:03): laser stop", "!stop"},
+		{"label before filler words", "Speaker 1: hey um laser stop", "!stop"},
+		{"lowercase label", "speaker 12: laser skip", "!skip"},
+		{"bracket label", "[SPEAKER_00] laser play random", "!pr"},
+		{"label on each line", "Speaker 1: um\nSpeaker 2: laser skip", "!skip"},
+		{"play query keeps label-like text", "Speaker 1: laser play speaker 2: the sequel", "!play speaker 2 the sequel"},
+		{"no label", "laser stop", "!stop"},
+		{"label without wake phrase", "Speaker 1: stop the music", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeakerLabels_DefaultOff(t *testing.T) {
+	svc := newTestService()
+
+	// The label's words count as fillers before the wake phrase, so a long
+	// label pushes it out of reach.
+	if got := parse(t, svc, "Speaker 1 (0:03): laser stop"); got != "" {
+		t.Errorf("parse with label and no patterns = %q, want no command", got)
+	}
+
+	if err := svc.SetSpeakerLabels([]string{`Speaker \d+ \(\d+:\d+\):`}); err != nil {
+		t.Fatalf("SetSpeakerLabels error: %v", err)
+	}
+	if err := svc.SetSpeakerLabels(nil); err != nil {
+		t.Fatalf("SetSpeakerLabels(nil) error: %v", err)
+	}
+	if got := parse(t, svc, "Speaker 1 (0:03): laser stop"); got != "" {
+		t.Errorf("parse after clearing patterns = %q, want no command", got)
+	}
+}
+
+func TestSpeakerLabels_InvalidPattern(t *testing.T) {
+	svc := newTestService()
+	if err := svc.SetSpeakerLabels([]string{`Speaker \d+ \(\d+:\d+\):`}); err != nil {
+		t.Fatalf("SetSpeakerLabels error: %v", err)
+	}
+
+	if err := svc.SetSpeakerLabels([]string{`(unclosed`}); err == nil {
+		t.Fatal("SetSpeakerLabels with invalid pattern: want error")
+	}
+	if got := parse(t, svc, "Speaker 1 (0:03): laser stop"); got != "!stop" {
+		t.Errorf("after rejected pattern = %q, want previous patterns kept", got)
+	}
+}
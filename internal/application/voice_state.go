@@ -0,0 +1,104 @@
+package application
+
+import "container/list"
+
+// defaultStateCapacity bounds each per-user state map so a busy bot doesn't
+// accumulate state for every user it has ever heard.
+const defaultStateCapacity = 10000
+
+// stateLRU is keyed state, usually per user, that evicts the least recently
+// used entry once it holds more than its capacity. It is not safe for
+// concurrent use; VoiceService guards it with mu.
+type stateLRU[K comparable, V any] struct {
+	capacity int // zero or less means unbounded
+	order    *list.List
+	entries  map[K]*list.Element
+}
+
+// stateEntry is the value stored in a stateLRU's order list.
+type stateEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newStateLRU[K comparable, V any](capacity int) *stateLRU[K, V] {
+	return &stateLRU[K, V]{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[K]*list.Element),
+	}
+}
+
+// set stores the value for key, evicting the least recently used entries if
+// the capacity is exceeded.
+func (m *stateLRU[K, V]) set(key K, value V) {
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*stateEntry[K, V]).value = value
+		m.order.MoveToFront(elem)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&stateEntry[K, V]{key: key, value: value})
+	m.evict()
+}
+
+// get returns the value for key, marking it as recently used.
+func (m *stateLRU[K, V]) get(key K) (V, bool) {
+	elem, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*stateEntry[K, V]).value, true
+}
+
+// take removes and returns the value for key.
+func (m *stateLRU[K, V]) take(key K) (V, bool) {
+	elem, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	m.order.Remove(elem)
+	delete(m.entries, key)
+	return elem.Value.(*stateEntry[K, V]).value, true
+}
+
+// deleteFunc removes every entry whose key matches.
+func (m *stateLRU[K, V]) deleteFunc(match func(K) bool) {
+	for key, elem := range m.entries {
+		if match(key) {
+			m.order.Remove(elem)
+			delete(m.entries, key)
+		}
+	}
+}
+
+// setCapacity changes the capacity, evicting entries that no longer fit.
+func (m *stateLRU[K, V]) setCapacity(capacity int) {
+	m.capacity = capacity
+	m.evict()
+}
+
+// evict drops least recently used entries until the map fits its capacity.
+func (m *stateLRU[K, V]) evict() {
+	for m.capacity > 0 && len(m.entries) > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*stateEntry[K, V]).key)
+	}
+}
+
+// SetStateCapacity limits how many users' state VoiceService keeps for each
+// kind of per-user state, such as pending confirmations (default 10000). The
+// least recently used entries are evicted first. Zero or less means unbounded.
+func (s *VoiceService) SetStateCapacity(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending.setCapacity(n)
+	s.wakeBuffer.setCapacity(n)
+	s.previews.setCapacity(n)
+	s.candidates.setCapacity(n)
+	s.results.setCapacity(n)
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStateLRU_EvictsOldest(t *testing.T) {
+	m := newStateLRU[stateKey, int](3)
+	for i := range 5 {
+		m.set(userKey("ch1", fmt.Sprint(i)), i)
+	}
+
+	for i := range 2 {
+		if _, ok := m.take(userKey("ch1", fmt.Sprint(i))); ok {
+			t.Errorf("entry %d survived, want evicted", i)
+		}
+	}
+	for i := 2; i < 5; i++ {
+		if got, ok := m.take(userKey("ch1", fmt.Sprint(i))); !ok || got != i {
+			t.Errorf("entry %d = (%d, %v), want kept", i, got, ok)
+		}
+	}
+}
+
+func TestStateLRU_SetRefreshesEntry(t *testing.T) {
+	m := newStateLRU[stateKey, string](2)
+	m.set(userKey("ch1", "a"), "a1")
+	m.set(userKey("ch1", "b"), "b1")
+	m.set(userKey("ch1", "a"), "a2")
+	m.set(userKey("ch1", "c"), "c1")
+
+	if _, ok := m.take(userKey("ch1", "b")); ok {
+		t.Error("least recently used entry b survived")
+	}
+	if got, ok := m.take(userKey("ch1", "a")); !ok || got != "a2" {
+		t.Errorf("entry a = (%q, %v), want (%q, true)", got, ok, "a2")
+	}
+}
+
+func TestStateLRU_Unbounded(t *testing.T) {
+	m := newStateLRU[stateKey, int](0)
+	for i := range 100 {
+		m.set(userKey("ch1", fmt.Sprint(i)), i)
+	}
+	if _, ok := m.take(userKey("ch1", "0")); !ok {
+		t.Error("unbounded map evicted an entry")
+	}
+}
+
+func TestSetStateCapacity(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newConfirmService(stt)
+	svc.SetStateCapacity(2)
+
+	for _, user := range []string{"u1", "u2", "u3"} {
+		handle(t, svc, stt, user, "laser clear the queue")
+	}
+
+	if got := handle(t, svc, stt, "u1", "laser confirm"); got != "" {
+		t.Errorf("oldest user's confirm = %q, want evicted", got)
+	}
+	for _, user := range []string{"u2", "u3"} {
+		if got := handle(t, svc, stt, user, "laser confirm"); got != "!clear" {
+			t.Errorf("%s confirm = %q, want %q", user, got, "!clear")
+		}
+	}
+}
+
+func TestSetStateCapacity_Shrinks(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newConfirmService(stt)
+
+	for _, user := range []string{"u1", "u2", "u3"} {
+		handle(t, svc, stt, user, "laser clear the queue")
+	}
+	svc.SetStateCapacity(1)
+
+	for _, user := range []string{"u1", "u2"} {
+		if got := handle(t, svc, stt, user, "laser confirm"); got != "" {
+			t.Errorf("%s confirm = %q, want evicted", user, got)
+		}
+	}
+	if got := handle(t, svc, stt, "u3", "laser confirm"); got != "!clear" {
+		t.Errorf("most recent confirm = %q, want %q", got, "!clear")
+	}
+}
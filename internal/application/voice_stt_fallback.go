@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+	"log"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// SetSTTFallbacks sets STT services to try, in order, when the primary one
+// returns an error, e.g. a second provider for when the first is down. If all
+// of them fail, the last error is returned. Passing none removes the fallbacks.
+func (s *VoiceService) SetSTTFallbacks(fallbacks ...bot.STTService) {
+	s.sttFallbacks = fallbacks
+}
+
+// transcribeWithFallbacks transcribes the audio with the primary STT service,
+// then each fallback until one succeeds. It stops early if ctx is done.
+func (s *VoiceService) transcribeWithFallbacks(ctx context.Context, audio []byte, format bot.AudioFormat) (string, error) {
+	text, err := transcribeWith(ctx, s.stt, audio, format)
+	for i, stt := range s.sttFallbacks {
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+		log.Printf("transcription failed, trying fallback STT %d: %v", i+1, err)
+		text, err = transcribeWith(ctx, stt, audio, format)
+	}
+	return text, err
+}
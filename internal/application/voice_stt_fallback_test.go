@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSTTFallbacks_SecondSucceeds(t *testing.T) {
+	primary := &mockSTT{err: errors.New("500 internal server error")}
+	fallback := &mockSTT{text: "laser skip"}
+	svc := NewVoiceService(primary, "laser", nil, nil)
+	svc.SetSTTFallbacks(fallback)
+
+	result, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if err != nil {
+		t.Fatalf("HandleVoiceDetailed error: %v", err)
+	}
+	if result.Command != "!skip" || result.Transcription != "laser skip" {
+		t.Errorf("result = %+v, want the fallback's transcription and !skip", result)
+	}
+	if primary.calls != 1 || fallback.calls != 1 {
+		t.Errorf("calls = %d primary, %d fallback; want 1 each", primary.calls, fallback.calls)
+	}
+	if string(fallback.audio) != "fake-audio" {
+		t.Errorf("fallback audio = %q, want the clip", fallback.audio)
+	}
+}
+
+func TestSTTFallbacks_PrimarySucceeds(t *testing.T) {
+	primary := &mockSTT{text: "laser stop"}
+	fallback := &mockSTT{text: "laser skip"}
+	svc := NewVoiceService(primary, "laser", nil, nil)
+	svc.SetSTTFallbacks(fallback)
+
+	if got := handle(t, svc, primary, "u1", "laser stop"); got != "!stop" {
+		t.Errorf("HandleVoice = %q, want %q", got, "!stop")
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback called %d times, want 0", fallback.calls)
+	}
+}
+
+func TestSTTFallbacks_AllFail(t *testing.T) {
+	lastErr := errors.New("fallback unavailable")
+	first := &mockSTT{err: errors.New("primary unavailable")}
+	second := &mockSTT{err: errors.New("second unavailable")}
+	third := &mockSTT{err: lastErr}
+	svc := NewVoiceService(first, "laser", nil, nil)
+	svc.SetSTTFallbacks(second, third)
+
+	_, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio"))
+	if !errors.Is(err, lastErr) {
+		t.Fatalf("HandleVoice error = %v, want the last error %v", err, lastErr)
+	}
+	if first.calls != 1 || second.calls != 1 || third.calls != 1 {
+		t.Errorf("calls = %d, %d, %d; want each STT tried once", first.calls, second.calls, third.calls)
+	}
+}
+
+func TestSTTFallbacks_StopsWhenCancelled(t *testing.T) {
+	primary := &blockingSTT{started: make(chan struct{})}
+	fallback := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(primary, "laser", nil, nil)
+	svc.SetSTTFallbacks(fallback)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-primary.started
+		cancel()
+	}()
+
+	_, err := svc.HandleVoice(ctx, "ch1", "u1", []byte("fake-audio"))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("HandleVoice error = %v, want %v", err, context.Canceled)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback called %d times after cancellation, want 0", fallback.calls)
+	}
+}
@@ -0,0 +1,30 @@
+package application
+
+import "strings"
+
+// SetAllowTrailingWake lets the wake phrase come at the end of a command, as in
+// "stop, laser" or "play random laser", for users who say the command first.
+// A leading wake phrase is still tried first. Off by default, since a trailing
+// "laser" is more often part of a play query than a leading one. It has no
+// effect when a wake regexp is set.
+func (s *VoiceService) SetAllowTrailingWake(allow bool) {
+	s.trailingWake = allow
+}
+
+// trailingWakeText returns the words before a wake phrase that ends the
+// transcription, if trailing wake phrases are allowed and those words start a
+// command, possibly negated. Otherwise "hey laser" would lose its meaning as a bare wake phrase.
+func (s *VoiceService) trailingWakeText(phrase string, fields []string) (commandWords, wakeMatch, bool) {
+	if !s.trailingWake || len(fields) < 2 {
+		return commandWords{}, wakeMatch{}, false
+	}
+	last := strings.TrimFunc(strings.ToLower(fields[len(fields)-1]), isWordEdge)
+	if !s.isWakeWord(phrase, last) {
+		return commandWords{}, wakeMatch{}, false
+	}
+	cw := s.skipLeadIn(newCommandWords(fields[:len(fields)-1]))
+	if !s.startsCommand(cw.text()) && !s.negatedCommand(cw) {
+		return commandWords{}, wakeMatch{}, false
+	}
+	return cw, wakeMatch{token: last}, true
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTrailingWake(t *testing.T) {
+	svc := newTestService()
+	svc.SetMaxInterveningWords(2)
+	svc.SetAllowTrailingWake(true)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"keyword", "stop laser", "!stop"},
+		{"punctuation", "Stop, laser.", "!stop"},
+		{"alternate spelling", "skip lazer", "!skip"},
+		{"play query", "play never gonna give you up laser", "!play never gonna give you up"},
+		{"play random", "play random, laser", "!pr"},
+		{"lead-in words", "could you pause laser", "!pause"},
+		{"leading wake still works", "laser stop", "!stop"},
+		{"leading wake wins", "laser play the laser", "!play the laser"},
+		{"wake only", "laser", ""},
+		{"wake in the middle", "stop laser now", ""},
+		{"no command", "hello there laser", ""},
+		{"filler before wake", "hey laser", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	trace, ok := svc.Explain(context.Background(), "stop lazer")
+	if !ok || trace.Command.WakeToken != "lazer" {
+		t.Errorf("Explain WakeToken = %q (ok=%v), want %q", trace.Command.WakeToken, ok, "lazer")
+	}
+}
+
+func TestTrailingWake_BareWakeBuffered(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newWakeBufferService(stt)
+	svc.SetAllowTrailingWake(true)
+
+	handle(t, svc, stt, "u1", "hey laser")
+	if got := handle(t, svc, stt, "u1", "stop"); got != "!stop" {
+		t.Errorf("buffered wake with trailing mode = %q, want %q", got, "!stop")
+	}
+}
+
+func TestTrailingWake_DefaultOff(t *testing.T) {
+	svc := newTestService()
+
+	for _, input := range []string{"stop laser", "play never gonna give you up laser"} {
+		if got := parse(t, svc, input); got != "" {
+			t.Errorf("parse(%q) = %q, want no command by default", input, got)
+		}
+	}
+}
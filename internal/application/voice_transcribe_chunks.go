@@ -0,0 +1,112 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// ErrAudioTooLong is the transcription error for audio longer than the
+// SetMaxTranscribeChunkBytes limit that can't be split: audio in an encoding
+// other than WAV, a malformed WAV file, or a limit too small for a WAV header
+// and one sample frame.
+var ErrAudioTooLong = errors.New("audio is longer than the chunk limit and can't be split")
+
+// SetMaxTranscribeChunkBytes splits WAV audio longer than n bytes into WAV
+// files of at most n bytes, transcribes each in order and joins the text, for
+// STT providers that reject long clips. The split falls between sample frames
+// and each piece gets its own header, so every piece is a playable file.
+// Container formats like Ogg can't be cut at arbitrary bytes, so longer audio
+// in any other encoding isn't transcribed and fails with ErrAudioTooLong
+// rather than reaching a provider that would reject it. Zero or less sends
+// audio whole (the default).
+func (s *VoiceService) SetMaxTranscribeChunkBytes(n int) {
+	s.chunkBytes = max(n, 0)
+}
+
+// transcribeChunked transcribes the audio, in chunks if it is longer than the
+// chunk size. It stops at the first chunk that fails.
+func (s *VoiceService) transcribeChunked(ctx context.Context, audio []byte, format bot.AudioFormat) (string, error) {
+	if s.chunkBytes == 0 || len(audio) <= s.chunkBytes {
+		return s.transcribeWithFallbacks(ctx, audio, format)
+	}
+	var chunks [][]byte
+	if strings.EqualFold(format.Encoding, "wav") {
+		chunks = splitWAV(audio, s.chunkBytes)
+	}
+	if chunks == nil {
+		return "", fmt.Errorf("%w: %d bytes of %s audio", ErrAudioTooLong, len(audio), format.Encoding)
+	}
+
+	var parts []string
+	for _, chunk := range chunks {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		text, err := s.transcribeWithFallbacks(ctx, chunk, format)
+		if err != nil {
+			return "", err
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// splitWAV splits a RIFF WAVE file into files of at most n bytes, each with a
+// copy of the original header and the sizes rewritten. Returns nil if audio
+// isn't a WAV file or n can't fit the header and one sample frame.
+func splitWAV(audio []byte, n int) [][]byte {
+	if len(audio) < 12 || !bytes.Equal(audio[:4], []byte("RIFF")) || !bytes.Equal(audio[8:12], []byte("WAVE")) {
+		return nil
+	}
+
+	// Walk the chunks for the frame size in "fmt " and the start of "data".
+	blockAlign := 0
+	for pos := 12; pos+8 <= len(audio); {
+		id := string(audio[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(audio[pos+4 : pos+8]))
+		body := pos + 8
+		switch id {
+		case "fmt ":
+			if size < 16 || body+16 > len(audio) {
+				return nil
+			}
+			blockAlign = int(binary.LittleEndian.Uint16(audio[body+12 : body+14]))
+		case "data":
+			if blockAlign <= 0 {
+				return nil
+			}
+			return splitWAVData(audio[:body], audio[body:min(body+size, len(audio))], blockAlign, n)
+		}
+		pos = body + size + size%2 // chunks are padded to an even length
+	}
+	return nil
+}
+
+// splitWAVData cuts data into whole frames that fit n bytes after header, and
+// prefixes each with header, its RIFF and data sizes set for the piece.
+func splitWAVData(header, data []byte, blockAlign, n int) [][]byte {
+	per := (n - len(header)) / blockAlign * blockAlign
+	if per <= 0 {
+		return nil
+	}
+
+	var chunks [][]byte
+	for start := 0; start < len(data); start += per {
+		piece := data[start:min(start+per, len(data))]
+		chunk := make([]byte, 0, len(header)+len(piece))
+		chunk = append(chunk, header...)
+		chunk = append(chunk, piece...)
+		binary.LittleEndian.PutUint32(chunk[4:8], uint32(len(chunk)-8))
+		binary.LittleEndian.PutUint32(chunk[len(header)-4:len(header)], uint32(len(piece)))
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// chunkSTT returns its texts in order and records the audio of each call.
+type chunkSTT struct {
+	texts  []string
+	err    error // returned instead of the text on call failAt
+	failAt int
+	chunks [][]byte
+}
+
+func (m *chunkSTT) Transcribe(_ context.Context, audio []byte) (string, error) {
+	m.chunks = append(m.chunks, audio)
+	n := len(m.chunks)
+	if m.err != nil && n == m.failAt {
+		return "", m.err
+	}
+	if n > len(m.texts) {
+		return "", nil
+	}
+	return m.texts[n-1], nil
+}
+
+// testWAV returns a 16-bit stereo WAV file with dataBytes of sample data.
+func testWAV(dataBytes int) []byte {
+	wav := make([]byte, 44, 44+dataBytes)
+	copy(wav[0:], "RIFF")
+	binary.LittleEndian.PutUint32(wav[4:], uint32(36+dataBytes))
+	copy(wav[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(wav[16:], 16)
+	binary.LittleEndian.PutUint16(wav[20:], 1)
+	binary.LittleEndian.PutUint16(wav[22:], 2)
+	binary.LittleEndian.PutUint32(wav[24:], 48000)
+	binary.LittleEndian.PutUint32(wav[28:], 48000*4)
+	binary.LittleEndian.PutUint16(wav[32:], 4)
+	binary.LittleEndian.PutUint16(wav[34:], 16)
+	copy(wav[36:], "data")
+	binary.LittleEndian.PutUint32(wav[40:], uint32(dataBytes))
+	return append(wav, bytes.Repeat([]byte{1}, dataBytes)...)
+}
+
+func TestMaxTranscribeChunkBytes(t *testing.T) {
+	stt := &chunkSTT{texts: []string{"laser play never", " gonna give ", "you up"}}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetMaxTranscribeChunkBytes(1002)
+
+	// 1002 bytes hold the header and 239 four-byte frames of data.
+	result, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "u1", testWAV(2*956+500))
+	if err != nil {
+		t.Fatalf("HandleVoiceDetailed error: %v", err)
+	}
+	if len(stt.chunks) != 3 {
+		t.Fatalf("Transcribe called %d times, want 3", len(stt.chunks))
+	}
+	for i, want := range []int{956, 956, 500} {
+		chunk := stt.chunks[i]
+		if !bytes.Equal(chunk[:44], testWAV(want)[:44]) || len(chunk) != 44+want {
+			t.Errorf("chunk %d = %d bytes with header %v, want a WAV file of %d data bytes", i, len(chunk), chunk[:44], want)
+		}
+	}
+	if want := "laser play never gonna give you up"; result.Transcription != want {
+		t.Errorf("Transcription = %q, want %q", result.Transcription, want)
+	}
+	if want := "!play never gonna give you up"; result.Command != want {
+		t.Errorf("Command = %q, want %q", result.Command, want)
+	}
+}
+
+func TestMaxTranscribeChunkBytes_ShortAudioWhole(t *testing.T) {
+	stt := &chunkSTT{texts: []string{"laser stop"}}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetMaxTranscribeChunkBytes(1000)
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", testWAV(956))
+	if err != nil || got != "!stop" {
+		t.Fatalf("HandleVoice = %q, %v; want %q", got, err, "!stop")
+	}
+	if len(stt.chunks) != 1 || len(stt.chunks[0]) != 1000 {
+		t.Errorf("Transcribe calls = %d, want the audio sent whole once", len(stt.chunks))
+	}
+}
+
+func TestMaxTranscribeChunkBytes_NotSplittable(t *testing.T) {
+	tests := []struct {
+		name   string
+		audio  []byte
+		format bot.AudioFormat
+		limit  int
+	}{
+		{"not a WAV file", bytes.Repeat([]byte{1}, 3000), DefaultAudioFormat, 1000},
+		{"other encoding", bytes.Repeat([]byte{1}, 3000), bot.AudioFormat{SampleRate: 48000, Channels: 2, Encoding: "ogg"}, 1000},
+		{"limit below one frame", testWAV(3000), DefaultAudioFormat, 46},
+	}
+	for _, tt := range tests {
+		stt := &chunkSTT{texts: []string{"laser stop"}}
+		svc := NewVoiceService(stt, "laser", nil, nil)
+		svc.SetMaxTranscribeChunkBytes(tt.limit)
+
+		got, err := svc.HandleVoiceWithFormat(context.Background(), "ch1", "u1", tt.audio, tt.format)
+		if !errors.Is(err, ErrAudioTooLong) || got != "" {
+			t.Errorf("%s: HandleVoiceWithFormat = %q, %v; want ErrAudioTooLong", tt.name, got, err)
+		}
+		if len(stt.chunks) != 0 {
+			t.Errorf("%s: Transcribe calls = %d, want none", tt.name, len(stt.chunks))
+		}
+	}
+}
+
+func TestMaxTranscribeChunkBytes_OtherEncodingWithinLimit(t *testing.T) {
+	stt := &chunkSTT{texts: []string{"laser stop"}}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetMaxTranscribeChunkBytes(1000)
+	audio := bytes.Repeat([]byte{1}, 1000)
+
+	got, err := svc.HandleVoiceWithFormat(context.Background(), "ch1", "u1", audio, bot.AudioFormat{SampleRate: 48000, Channels: 2, Encoding: "ogg"})
+	if err != nil || got != "!stop" {
+		t.Errorf("HandleVoiceWithFormat = %q, %v; want %q", got, err, "!stop")
+	}
+	if len(stt.chunks) != 1 || !bytes.Equal(stt.chunks[0], audio) {
+		t.Errorf("Transcribe calls = %d, want the audio sent whole once", len(stt.chunks))
+	}
+}
+
+func TestMaxTranscribeChunkBytes_ChunkFails(t *testing.T) {
+	sttErr := errors.New("chunk rejected")
+	stt := &chunkSTT{texts: []string{"laser play", "something"}, err: sttErr, failAt: 2}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetMaxTranscribeChunkBytes(60)
+
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", testWAV(48))
+	if !errors.Is(err, sttErr) || got != "" {
+		t.Fatalf("HandleVoice = %q, %v; want error %v", got, err, sttErr)
+	}
+	if len(stt.chunks) != 2 {
+		t.Errorf("Transcribe called %d times, want it to stop at the failed chunk", len(stt.chunks))
+	}
+}
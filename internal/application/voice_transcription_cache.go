@@ -0,0 +1,68 @@
+package application
+
+import (
+	"crypto/sha256"
+	"time"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// transcriptionCacheCapacity bounds how many recent transcriptions are kept.
+const transcriptionCacheCapacity = 1000
+
+// transcriptionKey identifies a clip by the hash of its bytes and its format.
+type transcriptionKey struct {
+	sum    [sha256.Size]byte
+	format bot.AudioFormat
+}
+
+// cachedTranscription is a transcription kept for reuse until it expires.
+type cachedTranscription struct {
+	text    string
+	expires time.Time
+}
+
+// SetTranscriptionCache reuses the transcription of a clip when identical audio
+// arrives again within ttl, e.g. a retransmitted packet, so it costs no STT
+// call. Unlike debouncing commands this skips transcription entirely; the
+// reused text is still parsed as usual. Failed transcriptions aren't cached.
+// Zero (the default) disables the cache and drops anything cached.
+func (s *VoiceService) SetTranscriptionCache(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transcriptTTL = ttl
+	s.transcripts = newStateLRU[transcriptionKey, cachedTranscription](transcriptionCacheCapacity)
+}
+
+// cachedTranscript returns the key for the audio and, if it was transcribed
+// within the cache TTL, the earlier transcription. The key is only meaningful
+// while the cache is enabled.
+func (s *VoiceService) cachedTranscript(audio []byte, format bot.AudioFormat) (transcriptionKey, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.transcriptTTL <= 0 {
+		return transcriptionKey{}, "", false
+	}
+	key := transcriptionKey{sum: sha256.Sum256(audio), format: format}
+	cached, ok := s.transcripts.get(key)
+	if !ok {
+		return key, "", false
+	}
+	if !s.clock.Now().Before(cached.expires) {
+		s.transcripts.take(key)
+		return key, "", false
+	}
+	return key, cached.text, true
+}
+
+// cacheTranscript stores a transcription for reuse if the cache is enabled.
+func (s *VoiceService) cacheTranscript(key transcriptionKey, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.transcriptTTL > 0 {
+		s.transcripts.set(key, cachedTranscription{text: text, expires: s.clock.Now().Add(s.transcriptTTL)})
+	}
+}
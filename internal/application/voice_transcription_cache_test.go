@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newCacheService(stt *mockSTT) (*VoiceService, *fakeClock) {
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	clock := newFakeClock()
+	svc.SetClock(clock)
+	svc.SetTranscriptionCache(2 * time.Second)
+	return svc, clock
+}
+
+// handleAudio runs HandleVoice on the given audio for user u1.
+func handleAudio(t *testing.T, svc *VoiceService, audio string) string {
+	t.Helper()
+	got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte(audio))
+	if err != nil {
+		t.Fatalf("HandleVoice(%q) error: %v", audio, err)
+	}
+	return got
+}
+
+func TestTranscriptionCache(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc, _ := newCacheService(stt)
+
+	handleAudio(t, svc, "clip-1")
+	stt.text = "laser skip"
+	if got := handleAudio(t, svc, "clip-1"); got != "!stop" {
+		t.Errorf("identical audio = %q, want the cached %q", got, "!stop")
+	}
+	if stt.calls != 1 {
+		t.Errorf("STT calls = %d, want 1", stt.calls)
+	}
+
+	if got := handleAudio(t, svc, "clip-2"); got != "!skip" {
+		t.Errorf("different audio = %q, want %q", got, "!skip")
+	}
+	if stt.calls != 2 {
+		t.Errorf("STT calls = %d, want 2", stt.calls)
+	}
+}
+
+func TestTranscriptionCache_Expires(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc, clock := newCacheService(stt)
+
+	handleAudio(t, svc, "clip-1")
+	clock.Advance(2 * time.Second)
+	handleAudio(t, svc, "clip-1")
+	if stt.calls != 2 {
+		t.Errorf("STT calls after the TTL = %d, want 2", stt.calls)
+	}
+}
+
+func TestTranscriptionCache_SkipsErrors(t *testing.T) {
+	stt := &mockSTT{err: errors.New("stt down")}
+	svc, _ := newCacheService(stt)
+
+	if _, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("clip-1")); err == nil {
+		t.Fatal("HandleVoice error = nil, want the STT error")
+	}
+	stt.err = nil
+	stt.text = "laser stop"
+	if got := handleAudio(t, svc, "clip-1"); got != "!stop" {
+		t.Errorf("after a failed transcription = %q, want %q", got, "!stop")
+	}
+	if stt.calls != 2 {
+		t.Errorf("STT calls = %d, want 2", stt.calls)
+	}
+}
+
+func TestTranscriptionCache_Disabled(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc, _ := newCacheService(stt)
+
+	handleAudio(t, svc, "clip-1")
+	svc.SetTranscriptionCache(0)
+	handleAudio(t, svc, "clip-1")
+	if stt.calls != 2 {
+		t.Errorf("STT calls with the cache disabled = %d, want 2", stt.calls)
+	}
+}
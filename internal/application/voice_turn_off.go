@@ -0,0 +1,49 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+)
+
+// turnOffPhrases ask for the music to end. They name the music ("it", "that",
+// "the music") so "turn off the lights" is not mistaken for one; anything
+// else starting with "turn off" is left unmatched.
+var turnOffPhrases = []string{
+	"shut up", "turn it off", "turn that off", "turn off the music", "turn the music off",
+}
+
+// defaultTurnOffCommand is what "shut up" and "turn it off" send by default.
+const defaultTurnOffCommand = "stop"
+
+// defaultTurnOff returns the keyword command for defaultTurnOffCommand.
+func defaultTurnOff() *keywordCommand {
+	kc, _ := aliasTarget(defaultTurnOffCommand)
+	return &kc
+}
+
+// SetTurnOffCommand sets the command that "shut up", "turn it off" and
+// similar phrases send (default "stop"), e.g. "leave" to disconnect instead.
+// An empty name leaves those phrases unmatched. Returns ErrUnknownCommand if
+// there is no such keyword command.
+func (s *VoiceService) SetTurnOffCommand(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		s.turnOff = nil
+		return nil
+	}
+	kc, ok := aliasTarget(name)
+	if !ok {
+		return fmt.Errorf("turn off command: %w: %q", ErrUnknownCommand, name)
+	}
+	s.turnOff = &kc
+	return nil
+}
+
+// matchTurnOff returns the command for a turn-off phrase, if one starts text
+// and turn-off phrases are enabled.
+func (s *VoiceService) matchTurnOff(text string) (keywordCommand, bool) {
+	if s.turnOff == nil || !hasAnyPhrasePrefix(text, turnOffPhrases) {
+		return keywordCommand{}, false
+	}
+	return *s.turnOff, true
+}
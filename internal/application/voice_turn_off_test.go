@@ -0,0 +1,70 @@
+package application
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTurnOffPhrases(t *testing.T) {
+	svc := newTestService()
+	svc.SetMaxInterveningWords(2)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"shut up", "laser shut up", "!stop"},
+		{"turn it off", "laser turn it off", "!stop"},
+		{"turn that off", "laser turn that off", "!stop"},
+		{"turn off the music", "laser turn off the music", "!stop"},
+		{"turn the music off", "laser turn the music off", "!stop"},
+		{"caps and punctuation", "LASER SHUT UP!", "!stop"},
+		{"lead-in", "laser could you turn it off", "!stop"},
+		{"other things are not the music", "laser turn off the lights", ""},
+		{"bare turn off", "laser turn off", ""},
+		{"turn it down is volume", "laser turn it down", "!volume -10"},
+		{"turn it up is volume", "laser turn it up", "!volume +10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parse(t, svc, tt.input); got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTurnOffPhrases_RequirePlayback(t *testing.T) {
+	svc := newTestService()
+	svc.SetPlaybackState(&mockPlayback{})
+
+	if got := parse(t, svc, "laser shut up"); got != "" {
+		t.Errorf("shut up while idle = %q, want refused like stop", got)
+	}
+}
+
+func TestSetTurnOffCommand(t *testing.T) {
+	svc := newTestService()
+
+	if err := svc.SetTurnOffCommand("Leave"); err != nil {
+		t.Fatalf("SetTurnOffCommand error: %v", err)
+	}
+	if got := parse(t, svc, "laser turn it off"); got != "!leave" {
+		t.Errorf("turn it off mapped to leave = %q, want %q", got, "!leave")
+	}
+	if got := parse(t, svc, "laser stop"); got != "!stop" {
+		t.Errorf("stop = %q, want it unchanged", got)
+	}
+
+	if err := svc.SetTurnOffCommand(""); err != nil {
+		t.Fatalf("SetTurnOffCommand(\"\") error: %v", err)
+	}
+	if got := parse(t, svc, "laser shut up"); got != "" {
+		t.Errorf("shut up when disabled = %q, want no command", got)
+	}
+
+	if err := svc.SetTurnOffCommand("lights"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("SetTurnOffCommand(lights) error = %v, want %v", err, ErrUnknownCommand)
+	}
+}
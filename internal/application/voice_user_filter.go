@@ -0,0 +1,36 @@
+package application
+
+// SetUserDenylist sets user IDs whose audio is ignored without being
+// transcribed, e.g. another bot relaying audio into the channel. It replaces
+// any previous denylist; nil or empty denies no one.
+func (s *VoiceService) SetUserDenylist(userIDs []string) {
+	s.deniedUsers = idSet(userIDs)
+}
+
+// SetUserAllowlist restricts voice commands to the given user IDs; audio from
+// anyone else is ignored without being transcribed. It replaces any previous
+// allowlist; nil or empty allows everyone. The denylist takes precedence, so a
+// user on both lists is ignored.
+func (s *VoiceService) SetUserAllowlist(userIDs []string) {
+	s.allowedUsers = idSet(userIDs)
+}
+
+// userIgnored reports whether audio from the user should be dropped.
+func (s *VoiceService) userIgnored(userID string) bool {
+	if s.deniedUsers[userID] {
+		return true
+	}
+	return len(s.allowedUsers) > 0 && !s.allowedUsers[userID]
+}
+
+// idSet builds a set from IDs, skipping empty ones. Unlike wordSet the IDs are
+// kept as given.
+func idSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			set[id] = true
+		}
+	}
+	return set
+}
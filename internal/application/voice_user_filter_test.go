@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUserDenylist(t *testing.T) {
+	stt := &mockSTT{text: "laser stop"}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetUserDenylist([]string{"bot1"})
+
+	got, err := svc.HandleVoiceDetailed(context.Background(), "ch1", "bot1", []byte("fake-audio"))
+	if err != nil || got.Command != "" || got.Outcome != OutcomeIgnoredUser {
+		t.Errorf("denied user = %+v, %v; want outcome %q", got, err, OutcomeIgnoredUser)
+	}
+	if stt.calls != 0 {
+		t.Errorf("STT called %d times for a denied user, want 0", stt.calls)
+	}
+
+	if got := handle(t, svc, stt, "u1", "laser stop"); got != "!stop" {
+		t.Errorf("other user = %q, want %q", got, "!stop")
+	}
+
+	svc.SetUserDenylist(nil)
+	if got := handle(t, svc, stt, "bot1", "laser stop"); got != "!stop" {
+		t.Errorf("after clearing denylist = %q, want %q", got, "!stop")
+	}
+}
+
+func TestUserAllowlist(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetUserAllowlist([]string{"u1", "u2"})
+	svc.SetUserDenylist([]string{"u2"})
+
+	tests := []struct {
+		userID string
+		want   string
+	}{
+		{"u1", "!stop"},
+		{"u2", ""}, // the denylist wins over the allowlist
+		{"u3", ""},
+	}
+	for _, tt := range tests {
+		calls := stt.calls
+		if got := handle(t, svc, stt, tt.userID, "laser stop"); got != tt.want {
+			t.Errorf("user %s = %q, want %q", tt.userID, got, tt.want)
+		}
+		if transcribed := stt.calls > calls; transcribed != (tt.want != "") {
+			t.Errorf("user %s transcribed = %v, want %v", tt.userID, transcribed, tt.want != "")
+		}
+	}
+
+	svc.SetUserAllowlist([]string{})
+	if got := handle(t, svc, stt, "u3", "laser stop"); got != "!stop" {
+		t.Errorf("after clearing allowlist = %q, want %q", got, "!stop")
+	}
+}
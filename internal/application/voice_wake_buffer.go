@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// bufferedWake is a transcription that ended right after the wake phrase.
+type bufferedWake struct {
+	text    string
+	expires time.Time
+}
+
+// SetWakeBufferWindow enables joining a transcription that is only the wake
+// phrase (e.g. "laser") with the same user's next transcription if it arrives
+// within d, for when STT splits "laser stop" into two segments. Zero disables it.
+func (s *VoiceService) SetWakeBufferWindow(d time.Duration) {
+	s.wakeWindow = d
+}
+
+// parseBuffered parses a transcription, taking a buffered wake phrase for the
+// user into account. A bare wake phrase is buffered and produces no command.
+func (s *VoiceService) parseBuffered(ctx context.Context, key stateKey, text string) (CommandTrace, bool) {
+	trace, ok := s.explain(ctx, key.channelID, text)
+	if ok || s.wakeWindow <= 0 {
+		s.dropBufferedWake(key)
+		return trace, ok
+	}
+
+	if s.isBareWake(key.channelID, text) {
+		s.mu.Lock()
+		s.wakeBuffer.set(key, bufferedWake{text: text, expires: s.clock.Now().Add(s.wakeWindow)})
+		s.mu.Unlock()
+		return trace, false
+	}
+
+	prefix, found := s.takeBufferedWake(key)
+	if !found {
+		return trace, false
+	}
+	return s.explain(ctx, key.channelID, prefix+" "+text)
+}
+
+// isBareWake reports whether text is the channel's wake phrase with nothing but
+// noise words after it.
+func (s *VoiceService) isBareWake(channelID, text string) bool {
+	rest, wake, ok := s.commandText(s.wakePhraseFor(channelID), text)
+	return ok && wake.token != "" && len(s.dropNoise(rest).words) == 0
+}
+
+// takeBufferedWake removes and returns the user's buffered wake phrase if it
+// hasn't expired.
+func (s *VoiceService) takeBufferedWake(key stateKey) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buffered, found := s.wakeBuffer.take(key)
+	if !found || !s.clock.Now().Before(buffered.expires) {
+		return "", false
+	}
+	return buffered.text, true
+}
+
+// dropBufferedWake discards any buffered wake phrase for the user.
+func (s *VoiceService) dropBufferedWake(key stateKey) {
+	s.mu.Lock()
+	s.wakeBuffer.take(key)
+	s.mu.Unlock()
+}
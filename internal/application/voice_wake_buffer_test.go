@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// echoSTT transcribes audio as its own bytes, so concurrent callers can each
+// send their own text. It is safe for concurrent use.
+type echoSTT struct{}
+
+func (echoSTT) Transcribe(_ context.Context, audio []byte) (string, error) {
+	return string(audio), nil
+}
+
+func newWakeBufferService(stt *mockSTT) (*VoiceService, *fakeClock) {
+	svc := NewVoiceService(stt, "laser", nil, nil)
+	svc.SetWakeBufferWindow(3 * time.Second)
+
+	clock := newFakeClock()
+	svc.SetClock(clock)
+	return svc, clock
+}
+
+func TestWakeBuffer_SplitSegments(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  string
+		second string
+		want   string
+	}{
+		{"stop", "laser", "stop", "!stop"},
+		{"filler and punctuation", "Hey laser", "Stop!", "!stop"},
+		{"play query", "laser", "play Daft Punk", "!play Daft Punk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stt := &mockSTT{}
+			svc, clock := newWakeBufferService(stt)
+
+			if got := handle(t, svc, stt, "u1", tt.first); got != "" {
+				t.Fatalf("bare wake phrase produced %q", got)
+			}
+			clock.Advance(time.Second)
+			if got := handle(t, svc, stt, "u1", tt.second); got != tt.want {
+				t.Errorf("second segment = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWakeBuffer_Expires(t *testing.T) {
+	stt := &mockSTT{}
+	svc, clock := newWakeBufferService(stt)
+
+	handle(t, svc, stt, "u1", "laser")
+	clock.Advance(4 * time.Second)
+
+	if got := handle(t, svc, stt, "u1", "stop"); got != "" {
+		t.Errorf("segment after window = %q, want no command", got)
+	}
+}
+
+func TestWakeBuffer_ConsumedOnce(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newWakeBufferService(stt)
+
+	handle(t, svc, stt, "u1", "laser")
+	handle(t, svc, stt, "u1", "stop")
+
+	if got := handle(t, svc, stt, "u1", "stop"); got != "" {
+		t.Errorf("buffered wake phrase reused: %q", got)
+	}
+}
+
+func TestWakeBuffer_FullCommandClearsBuffer(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newWakeBufferService(stt)
+
+	handle(t, svc, stt, "u1", "laser")
+	if got := handle(t, svc, stt, "u1", "laser play random"); got != "!pr" {
+		t.Errorf("full command = %q, want %q", got, "!pr")
+	}
+	if got := handle(t, svc, stt, "u1", "stop"); got != "" {
+		t.Errorf("buffer survived a full command: %q", got)
+	}
+}
+
+func TestWakeBuffer_DisabledByDefault(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "laser", nil, nil)
+
+	handle(t, svc, stt, "u1", "laser")
+	if got := handle(t, svc, stt, "u1", "stop"); got != "" {
+		t.Errorf("segment without buffering = %q, want no command", got)
+	}
+}
+
+func TestWakeBuffer_IsolatedPerUser(t *testing.T) {
+	stt := &mockSTT{}
+	svc, _ := newWakeBufferService(stt)
+
+	handle(t, svc, stt, "u1", "laser")
+	handle(t, svc, stt, "u2", "laser")
+	if got := handle(t, svc, stt, "u2", "skip"); got != "!skip" {
+		t.Errorf("u2 follow-up = %q, want %q", got, "!skip")
+	}
+	if got := handle(t, svc, stt, "u1", "stop"); got != "!stop" {
+		t.Errorf("u1 follow-up = %q, want %q", got, "!stop")
+	}
+
+	handle(t, svc, stt, "u1", "laser")
+	if got := handle(t, svc, stt, "u2", "stop"); got != "" {
+		t.Errorf("u2 completed u1's wake phrase: %q", got)
+	}
+	if got := handle(t, svc, stt, "u1", "pause"); got != "!pause" {
+		t.Errorf("u1 follow-up after u2 spoke = %q, want %q", got, "!pause")
+	}
+}
+
+func TestWakeBuffer_IsolatedPerChannel(t *testing.T) {
+	svc := NewVoiceService(echoSTT{}, "laser", nil, nil)
+	svc.SetWakeBufferWindow(3 * time.Second)
+	say := func(channelID, text string) string {
+		t.Helper()
+		got, err := svc.HandleVoice(context.Background(), channelID, "u1", []byte(text))
+		if err != nil {
+			t.Fatalf("HandleVoice(%q, %q) error: %v", channelID, text, err)
+		}
+		return got
+	}
+
+	say("ch1", "laser")
+	if got := say("ch2", "stop"); got != "" {
+		t.Errorf("ch2 completed the ch1 wake phrase: %q", got)
+	}
+	if got := say("ch1", "stop"); got != "!stop" {
+		t.Errorf("ch1 follow-up = %q, want %q", got, "!stop")
+	}
+}
+
+func TestWakeBuffer_Concurrent(t *testing.T) {
+	svc := NewVoiceService(echoSTT{}, "laser", nil, nil)
+	svc.SetWakeBufferWindow(time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		userID := fmt.Sprintf("u%d", i)
+		command := []string{"stop", "skip", "pause", "resume"}[i%4]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if got, err := svc.HandleVoice(context.Background(), "ch1", userID, []byte("laser")); err != nil || got != "" {
+					t.Errorf("%s bare wake phrase = %q, %v", userID, got, err)
+					return
+				}
+				got, err := svc.HandleVoice(context.Background(), "ch1", userID, []byte(command))
+				if err != nil || got != "!"+command {
+					t.Errorf("%s follow-up = %q, %v; want %q", userID, got, err, "!"+command)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SetWakeRegexp makes wake detection use re instead of the wake phrase, its
+// alternates and the filler-word rules, for communities with several unrelated
+// wake words. re must have a named group "command" capturing the command text;
+// an optional group "wake" captures the wake word, and anything before the match
+// is reported as filler. Passing nil restores phrase-based detection.
+//
+// Example: (?i)\b(?P<wake>laser|jarvis|computer)\b[\s,]*(?P<command>.*)
+func (s *VoiceService) SetWakeRegexp(re *regexp.Regexp) error {
+	if re != nil && re.SubexpIndex("command") < 0 {
+		return fmt.Errorf("wake regexp %q has no named group \"command\"", re)
+	}
+	s.wakeRegexp = re
+	return nil
+}
+
+// matchWakeRegexp applies the wake regexp to the transcription, returning the
+// command words and how the wake word was spoken.
+func (s *VoiceService) matchWakeRegexp(transcription string) (commandWords, wakeMatch, bool) {
+	m := s.wakeRegexp.FindStringSubmatchIndex(transcription)
+	if m == nil {
+		return commandWords{}, wakeMatch{}, false
+	}
+
+	cmd := s.wakeRegexp.SubexpIndex("command")
+	cmdStart, cmdEnd := m[2*cmd], m[2*cmd+1]
+	if cmdStart < 0 {
+		return commandWords{}, wakeMatch{}, false
+	}
+
+	wakeStart, wakeEnd := m[0], cmdStart
+	if w := s.wakeRegexp.SubexpIndex("wake"); w >= 0 && m[2*w] >= 0 {
+		wakeStart, wakeEnd = m[2*w], m[2*w+1]
+	}
+
+	wake := wakeMatch{
+		token:  newCommandWords(strings.Fields(strings.ToLower(transcription[wakeStart:wakeEnd]))).text(),
+		filler: newCommandWords(strings.Fields(strings.ToLower(transcription[:wakeStart]))).text(),
+	}
+	return newCommandWords(s.transcriptWords(transcription[cmdStart:cmdEnd])), wake, true
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestWakeRegexp_MultipleWakeWords(t *testing.T) {
+	svc := newTestService()
+	re := regexp.MustCompile(`(?i)\b(?P<wake>computer|jarvis|buddy)\b[\s,]*(?P<command>.*)`)
+	if err := svc.SetWakeRegexp(re); err != nil {
+		t.Fatalf("SetWakeRegexp error: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		want       string
+		wantToken  string
+		wantFiller string
+	}{
+		{"first word", "computer stop", "!stop", "computer", ""},
+		{"second word", "Jarvis, play Daft Punk", "!play Daft Punk", "jarvis", ""},
+		{"third word with filler", "hey there buddy play random", "!pr", "buddy", "hey there"},
+		{"built-in phrase ignored", "laser stop", "", "", ""},
+		{"no wake word", "stop the music", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, ok := svc.parseCommand(context.Background(), tt.input)
+			got := ""
+			if ok {
+				got = cmd.Text
+			}
+			if got != tt.want {
+				t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if cmd.WakeToken != tt.wantToken || cmd.FillerPrefix != tt.wantFiller {
+				t.Errorf("wake = %q/%q, want %q/%q", cmd.WakeToken, cmd.FillerPrefix, tt.wantToken, tt.wantFiller)
+			}
+		})
+	}
+}
+
+func TestWakeRegexp_CommandGroupOnly(t *testing.T) {
+	svc := newTestService()
+	if err := svc.SetWakeRegexp(regexp.MustCompile(`^(?i)ok (?:bot|robot) (?P<command>.+)$`)); err != nil {
+		t.Fatalf("SetWakeRegexp error: %v", err)
+	}
+
+	cmd, ok := svc.parseCommand(context.Background(), "OK Robot stop")
+	if !ok || cmd.Text != "!stop" {
+		t.Fatalf("parse = %+v, %v, want !stop", cmd, ok)
+	}
+	if cmd.WakeToken != "ok robot" {
+		t.Errorf("WakeToken = %q, want %q", cmd.WakeToken, "ok robot")
+	}
+}
+
+func TestWakeRegexp_RequiresCommandGroup(t *testing.T) {
+	svc := newTestService()
+	if err := svc.SetWakeRegexp(regexp.MustCompile(`(?i)laser (.*)`)); err == nil {
+		t.Error("SetWakeRegexp without a command group should fail")
+	}
+	if got := parse(t, svc, "laser stop"); got != "!stop" {
+		t.Errorf("parse after rejected regexp = %q, want %q", got, "!stop")
+	}
+}
+
+func TestWakeRegexp_NilRestoresPhrase(t *testing.T) {
+	svc := newTestService()
+	svc.SetWakeRegexp(regexp.MustCompile(`(?i)jarvis (?P<command>.*)`))
+	svc.SetWakeRegexp(nil)
+
+	if got := parse(t, svc, "laser stop"); got != "!stop" {
+		t.Errorf("parse = %q, want %q", got, "!stop")
+	}
+}
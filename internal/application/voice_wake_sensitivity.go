@@ -0,0 +1,69 @@
+package application
+
+// wakeSensitivityCommand asks for the wake sensitivity to change. Its argument
+// is "up" or "down"; the receiving handler applies it with SetWakeSensitivity.
+const wakeSensitivityCommand = "wake-sensitivity"
+
+// MaxWakeSensitivity is the highest wake sensitivity: the wake word may be
+// this many letters off from the wake phrase.
+const MaxWakeSensitivity = 2
+
+// wakeSensitivityPhrases map spoken requests to a sensitivity direction. They
+// talk about sensitivity, so they can't be mistaken for "louder" or "quieter".
+var wakeSensitivityPhrases = []struct {
+	phrases   []string
+	direction string
+}{
+	{[]string{"be more sensitive", "more sensitive", "increase sensitivity", "increase the sensitivity"}, "up"},
+	{[]string{"be less sensitive", "less sensitive", "decrease sensitivity", "decrease the sensitivity"}, "down"},
+}
+
+// SetWakeSensitivity sets how loosely the wake word is matched: at level n a
+// word up to n letters off from the wake phrase wakes the bot, e.g. "laster"
+// for "laser" at level 1. Level 0 (the default) accepts only the wake phrase
+// and its alternates. The level is clamped to [0, MaxWakeSensitivity] and
+// only applies to wake phrases of at least four letters. It is safe to call
+// while voice clips are being handled, e.g. in response to
+// "!wake-sensitivity up".
+func (s *VoiceService) SetWakeSensitivity(level int) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+	s.wakeSensitivity = clampWakeSensitivity(level)
+}
+
+// WakeSensitivity returns the current wake sensitivity level.
+func (s *VoiceService) WakeSensitivity() int {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.wakeSensitivity
+}
+
+// clampWakeSensitivity limits level to [0, MaxWakeSensitivity].
+func clampWakeSensitivity(level int) int {
+	return min(max(level, 0), MaxWakeSensitivity)
+}
+
+// parseWakeSensitivity returns the direction of a wake sensitivity request,
+// e.g. "be more sensitive" → "up".
+func parseWakeSensitivity(text string) (string, bool) {
+	for _, ws := range wakeSensitivityPhrases {
+		if hasAnyPhrasePrefix(text, ws.phrases) {
+			return ws.direction, true
+		}
+	}
+	return "", false
+}
+
+// startsWakeSensitivity reports whether text starts with a wake sensitivity request.
+func startsWakeSensitivity(text string) bool {
+	_, ok := parseWakeSensitivity(text)
+	return ok
+}
+
+// nearWakeWord reports whether word is within the wake sensitivity of phrase.
+func (s *VoiceService) nearWakeWord(phrase, word string) bool {
+	if s.wakeSensitivity == 0 || len(phrase) < minFuzzyWordLength {
+		return false
+	}
+	return levenshtein([]rune(word), []rune(phrase)) <= s.wakeSensitivity
+}
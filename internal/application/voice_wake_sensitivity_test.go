@@ -0,0 +1,90 @@
+package application
+
+import "testing"
+
+func TestWakeSensitivityCommand(t *testing.T) {
+	svc := newTestService()
+	svc.SetMaxInterveningWords(2)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laser be more sensitive", "!wake-sensitivity up"},
+		{"laser more sensitive please", "!wake-sensitivity up"},
+		{"laser increase the sensitivity", "!wake-sensitivity up"},
+		{"laser be less sensitive", "!wake-sensitivity down"},
+		{"laser less sensitive", "!wake-sensitivity down"},
+		{"laser decrease sensitivity", "!wake-sensitivity down"},
+		{"laser could you be more sensitive", "!wake-sensitivity up"},
+		// Volume phrases keep their meaning.
+		{"laser louder", "!volume +10"},
+		{"laser quieter", "!volume -10"},
+		{"laser turn it up", "!volume +10"},
+		{"laser turn it down", "!volume -10"},
+		{"laser play more sensitive", "!play more sensitive"},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSetWakeSensitivity(t *testing.T) {
+	svc := newTestService()
+
+	if got := parse(t, svc, "laster stop"); got != "" {
+		t.Errorf("at level 0, parse(%q) = %q, want no command", "laster stop", got)
+	}
+
+	svc.SetWakeSensitivity(1)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"laster stop", "!stop"},
+		{"hey lase stop", "!stop"},
+		{"laser stop", "!stop"},
+		{"lazer stop", "!stop"},
+		{"loser stop", "!stop"},
+		{"lasers stop", "!stop"},
+		{"lover stop", ""},
+	}
+	for _, tt := range tests {
+		if got := parse(t, svc, tt.input); got != tt.want {
+			t.Errorf("at level 1, parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	svc.SetWakeSensitivity(2)
+	if got := parse(t, svc, "lover stop"); got != "!stop" {
+		t.Errorf("at level 2, parse(%q) = %q, want %q", "lover stop", got, "!stop")
+	}
+}
+
+func TestSetWakeSensitivity_Clamped(t *testing.T) {
+	svc := newTestService()
+
+	svc.SetWakeSensitivity(MaxWakeSensitivity + 5)
+	if got := svc.WakeSensitivity(); got != MaxWakeSensitivity {
+		t.Errorf("WakeSensitivity() = %d, want %d", got, MaxWakeSensitivity)
+	}
+	svc.SetWakeSensitivity(-1)
+	if got := svc.WakeSensitivity(); got != 0 {
+		t.Errorf("WakeSensitivity() = %d, want 0", got)
+	}
+}
+
+func TestSetWakeSensitivity_ShortWakePhrase(t *testing.T) {
+	stt := &mockSTT{}
+	svc := NewVoiceService(stt, "max", nil, nil)
+	svc.SetWakeSensitivity(MaxWakeSensitivity)
+
+	if got := parse(t, svc, "mix stop"); got != "" {
+		t.Errorf("parse(%q) = %q, want short wake phrases matched exactly", "mix stop", got)
+	}
+	if got := parse(t, svc, "max stop"); got != "!stop" {
+		t.Errorf("parse(%q) = %q, want %q", "max stop", got, "!stop")
+	}
+}
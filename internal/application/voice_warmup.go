@@ -0,0 +1,48 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// Warmup prepares the service for its first command: it fetches the play
+// options, filling any cache the options source keeps and indexing them for
+// matching, and pings the STT services, fallbacks included, and the LLM if they
+// implement bot.HealthChecker. Every step is attempted; the returned error
+// joins the failures. It is safe to call more than once, e.g. again after a
+// failure.
+func (s *VoiceService) Warmup(ctx context.Context) error {
+	var errs []error
+	if s.playOptions != nil {
+		if options, err := s.playOptions.GetOptions(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("get play options: %w", err))
+		} else {
+			s.optionIndexFor(options)
+		}
+	}
+	if err := ping(ctx, s.stt); err != nil {
+		errs = append(errs, fmt.Errorf("ping STT: %w", err))
+	}
+	for i, fallback := range s.sttFallbacks {
+		if err := ping(ctx, fallback); err != nil {
+			errs = append(errs, fmt.Errorf("ping STT fallback %d: %w", i+1, err))
+		}
+	}
+	if llm := s.activeLLM(); llm != nil {
+		if err := ping(ctx, llm); err != nil {
+			errs = append(errs, fmt.Errorf("ping LLM: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ping checks svc if it implements bot.HealthChecker.
+func ping(ctx context.Context, svc any) error {
+	if hc, ok := svc.(bot.HealthChecker); ok {
+		return hc.Ping(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// countingOptions counts GetOptions calls.
+type countingOptions struct {
+	mockPlayOptions
+	calls int
+}
+
+func (c *countingOptions) GetOptions(ctx context.Context) ([]bot.PlayOption, error) {
+	c.calls++
+	return c.mockPlayOptions.GetOptions(ctx)
+}
+
+// pingSTT is an STT service that implements bot.HealthChecker.
+type pingSTT struct {
+	mockSTT
+	err   error
+	pings int
+}
+
+func (p *pingSTT) Ping(context.Context) error {
+	p.pings++
+	return p.err
+}
+
+func TestWarmup(t *testing.T) {
+	opts := &countingOptions{mockPlayOptions: mockPlayOptions{options: []bot.PlayOption{{Name: "itsworking"}}}}
+	stt := &pingSTT{}
+	svc := NewVoiceService(stt, "laser", &mockLLM{reply: "itsworking"}, opts)
+
+	if err := svc.Warmup(context.Background()); err != nil {
+		t.Fatalf("Warmup error: %v", err)
+	}
+	if opts.calls != 1 || stt.pings != 1 {
+		t.Errorf("Warmup made %d GetOptions calls and %d pings, want 1 each", opts.calls, stt.pings)
+	}
+	warmed := svc.index
+	if warmed == nil {
+		t.Fatal("Warmup didn't index the play options")
+	}
+
+	stt.text = "laser play its working"
+	if got, err := svc.HandleVoice(context.Background(), "ch1", "u1", []byte("fake-audio")); err != nil || got != "!play itsworking" {
+		t.Fatalf("HandleVoice = %q, %v; want %q", got, err, "!play itsworking")
+	}
+	if svc.index != warmed {
+		t.Error("play rebuilt the option index instead of reusing the warmed one")
+	}
+
+	if err := svc.Warmup(context.Background()); err != nil {
+		t.Errorf("second Warmup error: %v", err)
+	}
+}
+
+func TestWarmup_Errors(t *testing.T) {
+	optsErr := errors.New("options API down")
+	pingErr := errors.New("bad API key")
+	svc := NewVoiceService(&pingSTT{err: pingErr}, "laser", nil, &mockPlayOptions{err: optsErr})
+
+	err := svc.Warmup(context.Background())
+	if !errors.Is(err, optsErr) || !errors.Is(err, pingErr) {
+		t.Errorf("Warmup error = %v, want both failures", err)
+	}
+
+	if err := NewVoiceService(&mockSTT{}, "laser", nil, nil).Warmup(context.Background()); err != nil {
+		t.Errorf("Warmup with nothing to warm = %v, want nil", err)
+	}
+}
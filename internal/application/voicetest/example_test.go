@@ -0,0 +1,24 @@
+package voicetest_test
+
+import (
+	"fmt"
+
+	"github.com/adrock-miles/go-laserbeak/internal/application"
+	"github.com/adrock-miles/go-laserbeak/internal/application/voicetest"
+)
+
+func ExampleParse() {
+	svc := application.NewVoiceService(nil, "jarvis", nil, nil)
+	if err := svc.AddAlias("stop", "halt"); err != nil {
+		panic(err)
+	}
+
+	for _, transcription := range []string{"jarvis halt", "hey jarvis skip", "laser stop"} {
+		cmd, ok := voicetest.Parse(svc, transcription)
+		fmt.Printf("%q → %q %v\n", transcription, cmd, ok)
+	}
+	// Output:
+	// "jarvis halt" → "!stop" true
+	// "hey jarvis skip" → "!skip" true
+	// "laser stop" → "" false
+}
@@ -0,0 +1,22 @@
+// Package voicetest helps test voice command configuration, such as aliases
+// and wake phrases, without reaching into VoiceService internals.
+package voicetest
+
+import (
+	"context"
+
+	"github.com/adrock-miles/go-laserbeak/internal/application"
+)
+
+// Parse returns the command text svc would send for the transcription, and
+// false if nothing would be sent. Like VoiceService.Explain it leaves
+// counters and per-user state untouched, so it doesn't matter whether a
+// command needs confirming, but play queries may still consult the service's
+// play options and LLM.
+func Parse(svc *application.VoiceService, transcription string) (string, bool) {
+	trace, ok := svc.Explain(context.Background(), transcription)
+	if !ok {
+		return "", false
+	}
+	return trace.Command.Text, true
+}
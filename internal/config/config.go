@@ -19,8 +19,10 @@ type Config struct {
 
 // PlayOptionsConfig holds settings for the play options API.
 type PlayOptionsConfig struct {
-	APIURL   string        // URL to fetch play options from (e.g. http://localhost:8080/options)
-	CacheTTL time.Duration // how long to cache the options list
+	APIURL        string        // URL to fetch play options from (e.g. http://localhost:8080/options)
+	CacheTTL      time.Duration // how long to cache the options list
+	LocalMatching bool          // fuzzy-match options locally when the LLM is unavailable
+	Phonetic      bool          // pick the option that sounds like the query before asking the LLM
 }
 
 // DiscordConfig holds Discord-specific settings.
@@ -34,13 +36,26 @@ type DiscordConfig struct {
 
 // LLMConfig holds LLM API settings.
 type LLMConfig struct {
-	APIKey  string
-	BaseURL string
-	Model   string
+	APIKey          string
+	BaseURL         string
+	Model           string
+	MaxConcurrent   int  // voice LLM calls allowed at once across all users; 0 is unlimited
+	BusyPassthrough bool // at the limit, send play queries unmatched instead of waiting
 }
 
 // STTConfig holds speech-to-text API settings.
 type STTConfig struct {
+	APIKey        string
+	BaseURL       string
+	Model         string
+	CacheTTL      time.Duration     // how long to reuse the transcription of identical audio; 0 disables
+	SpeakerLabels []string          // regexps for labels stripped from the start of transcription lines
+	MaxChunkBytes int               // split longer audio into chunks of this many bytes; 0 sends it whole
+	Fallback      STTProviderConfig // second provider tried when this one fails; unused without an API key
+}
+
+// STTProviderConfig holds the settings for an additional STT provider.
+type STTProviderConfig struct {
 	APIKey  string
 	BaseURL string
 	Model   string
@@ -48,9 +63,29 @@ type STTConfig struct {
 
 // BotConfig holds general bot behavior settings.
 type BotConfig struct {
-	SystemPrompt string
-	MaxHistory   int
-	WakePhrase   string // wake phrase for voice commands (e.g. "laser")
+	SystemPrompt     string
+	MaxHistory       int
+	WakePhrase       string            // wake phrase for voice commands (e.g. "laser")
+	FuzzyKeywords    bool              // match voice command keywords one typo away (e.g. "stob")
+	WakeSensitivity  int               // letters the spoken wake word may be off by (0-2)
+	TrailingWake     bool              // also accept the wake phrase after the command ("stop, laser")
+	FirstWordWake    int               // letters a first-word wake attempt may be off by (0-2), also split from "laserstop"
+	NoiseWords       []string          // words dropped from voice commands before matching (e.g. "um")
+	Placeholders     []string          // STT placeholder tokens dropped along with the defaults (e.g. "[applause]")
+	MinCommandWords  int               // words required after the wake phrase, noise words excluded
+	MaxIntervening   int               // non-filler words skipped before the command ("laser could you stop"); 0 skips only fillers
+	Aliases          map[string]string // extra voice phrase → command name (e.g. "halt": "stop")
+	Homophones       map[string]string // extra misheard first word → command keyword (e.g. "paws": "pause")
+	CommandPrefixes  map[string]string // command name → output prefix overriding the default (e.g. "leave": "/")
+	DeniedUsers      []string          // user IDs whose voice audio is ignored
+	AllowedUsers     []string          // if set, only these user IDs may give voice commands
+	AdminUsers       []string          // user IDs that may rename the bot by voice
+	DisabledCommands []string          // voice command names to refuse (e.g. "play")
+	HistoryFile      string            // JSON file keeping recent voice commands across restarts
+	TurnOffCommand   string            // command sent for "shut up" / "turn it off"; empty ignores them
+	BareWakeCommand  string            // command sent for the wake phrase alone (e.g. "listen"); empty ignores it
+	RandomSynonyms   []string          // whole voice commands that play a random track; empty keeps the defaults
+	RandomOutput     string            // full command text sent instead of "!pr" (e.g. "!play --random")
 }
 
 // Load reads configuration from environment variables, config files, and flags.
@@ -71,22 +106,48 @@ func Load() (*Config, error) {
 	// (e.g. DISCORD_TOKEN instead of LASERBEAK_DISCORD_TOKEN).
 	// The LASERBEAK_-prefixed version takes precedence when both are set.
 	envBindings := map[string][2]string{
-		"discord.token":          {"LASERBEAK_DISCORD_TOKEN", "DISCORD_TOKEN"},
-		"discord.commandprefix":  {"LASERBEAK_DISCORD_COMMANDPREFIX", "DISCORD_COMMANDPREFIX"},
-		"discord.guildid":        {"LASERBEAK_DISCORD_GUILDID", "DISCORD_GUILDID"},
-		"discord.voicechannelid": {"LASERBEAK_DISCORD_VOICECHANNELID", "DISCORD_VOICECHANNELID"},
-		"discord.textchannelid":  {"LASERBEAK_DISCORD_TEXTCHANNELID", "DISCORD_TEXTCHANNELID"},
-		"llm.apikey":             {"LASERBEAK_LLM_APIKEY", "LLM_APIKEY"},
-		"llm.baseurl":            {"LASERBEAK_LLM_BASEURL", "LLM_BASEURL"},
-		"llm.model":              {"LASERBEAK_LLM_MODEL", "LLM_MODEL"},
-		"stt.apikey":             {"LASERBEAK_STT_APIKEY", "STT_APIKEY"},
-		"stt.baseurl":            {"LASERBEAK_STT_BASEURL", "STT_BASEURL"},
-		"stt.model":              {"LASERBEAK_STT_MODEL", "STT_MODEL"},
-		"bot.systemprompt":       {"LASERBEAK_BOT_SYSTEMPROMPT", "BOT_SYSTEMPROMPT"},
-		"bot.maxhistory":         {"LASERBEAK_BOT_MAXHISTORY", "BOT_MAXHISTORY"},
-		"bot.wakephrase":         {"LASERBEAK_BOT_WAKEPHRASE", "BOT_WAKEPHRASE"},
-		"playoptions.apiurl":     {"LASERBEAK_PLAYOPTIONS_APIURL", "PLAYOPTIONS_APIURL"},
-		"playoptions.cachettl":   {"LASERBEAK_PLAYOPTIONS_CACHETTL", "PLAYOPTIONS_CACHETTL"},
+		"discord.token":             {"LASERBEAK_DISCORD_TOKEN", "DISCORD_TOKEN"},
+		"discord.commandprefix":     {"LASERBEAK_DISCORD_COMMANDPREFIX", "DISCORD_COMMANDPREFIX"},
+		"discord.guildid":           {"LASERBEAK_DISCORD_GUILDID", "DISCORD_GUILDID"},
+		"discord.voicechannelid":    {"LASERBEAK_DISCORD_VOICECHANNELID", "DISCORD_VOICECHANNELID"},
+		"discord.textchannelid":     {"LASERBEAK_DISCORD_TEXTCHANNELID", "DISCORD_TEXTCHANNELID"},
+		"llm.apikey":                {"LASERBEAK_LLM_APIKEY", "LLM_APIKEY"},
+		"llm.baseurl":               {"LASERBEAK_LLM_BASEURL", "LLM_BASEURL"},
+		"llm.model":                 {"LASERBEAK_LLM_MODEL", "LLM_MODEL"},
+		"llm.maxconcurrent":         {"LASERBEAK_LLM_MAXCONCURRENT", "LLM_MAXCONCURRENT"},
+		"llm.busypassthrough":       {"LASERBEAK_LLM_BUSYPASSTHROUGH", "LLM_BUSYPASSTHROUGH"},
+		"stt.apikey":                {"LASERBEAK_STT_APIKEY", "STT_APIKEY"},
+		"stt.baseurl":               {"LASERBEAK_STT_BASEURL", "STT_BASEURL"},
+		"stt.model":                 {"LASERBEAK_STT_MODEL", "STT_MODEL"},
+		"stt.cachettl":              {"LASERBEAK_STT_CACHETTL", "STT_CACHETTL"},
+		"stt.maxchunkbytes":         {"LASERBEAK_STT_MAXCHUNKBYTES", "STT_MAXCHUNKBYTES"},
+		"stt.speakerlabels":         {"LASERBEAK_STT_SPEAKERLABELS", "STT_SPEAKERLABELS"},
+		"stt.fallback.apikey":       {"LASERBEAK_STT_FALLBACK_APIKEY", "STT_FALLBACK_APIKEY"},
+		"stt.fallback.baseurl":      {"LASERBEAK_STT_FALLBACK_BASEURL", "STT_FALLBACK_BASEURL"},
+		"stt.fallback.model":        {"LASERBEAK_STT_FALLBACK_MODEL", "STT_FALLBACK_MODEL"},
+		"bot.systemprompt":          {"LASERBEAK_BOT_SYSTEMPROMPT", "BOT_SYSTEMPROMPT"},
+		"bot.maxhistory":            {"LASERBEAK_BOT_MAXHISTORY", "BOT_MAXHISTORY"},
+		"bot.wakephrase":            {"LASERBEAK_BOT_WAKEPHRASE", "BOT_WAKEPHRASE"},
+		"bot.fuzzykeywords":         {"LASERBEAK_BOT_FUZZYKEYWORDS", "BOT_FUZZYKEYWORDS"},
+		"bot.wakesensitivity":       {"LASERBEAK_BOT_WAKESENSITIVITY", "BOT_WAKESENSITIVITY"},
+		"bot.trailingwake":          {"LASERBEAK_BOT_TRAILINGWAKE", "BOT_TRAILINGWAKE"},
+		"bot.firstwordwake":         {"LASERBEAK_BOT_FIRSTWORDWAKE", "BOT_FIRSTWORDWAKE"},
+		"bot.noisewords":            {"LASERBEAK_BOT_NOISEWORDS", "BOT_NOISEWORDS"},
+		"bot.placeholdertokens":     {"LASERBEAK_BOT_PLACEHOLDERTOKENS", "BOT_PLACEHOLDERTOKENS"},
+		"bot.mincommandwords":       {"LASERBEAK_BOT_MINCOMMANDWORDS", "BOT_MINCOMMANDWORDS"},
+		"bot.maxinterveningwords":   {"LASERBEAK_BOT_MAXINTERVENINGWORDS", "BOT_MAXINTERVENINGWORDS"},
+		"bot.deniedusers":           {"LASERBEAK_BOT_DENIEDUSERS", "BOT_DENIEDUSERS"},
+		"bot.allowedusers":          {"LASERBEAK_BOT_ALLOWEDUSERS", "BOT_ALLOWEDUSERS"},
+		"bot.adminusers":            {"LASERBEAK_BOT_ADMINUSERS", "BOT_ADMINUSERS"},
+		"bot.disabledcommands":      {"LASERBEAK_BOT_DISABLEDCOMMANDS", "BOT_DISABLEDCOMMANDS"},
+		"bot.historyfile":           {"LASERBEAK_BOT_HISTORYFILE", "BOT_HISTORYFILE"},
+		"bot.turnoffcommand":        {"LASERBEAK_BOT_TURNOFFCOMMAND", "BOT_TURNOFFCOMMAND"},
+		"bot.barewakecommand":       {"LASERBEAK_BOT_BAREWAKECOMMAND", "BOT_BAREWAKECOMMAND"},
+		"bot.randomoutput":          {"LASERBEAK_BOT_RANDOMOUTPUT", "BOT_RANDOMOUTPUT"},
+		"playoptions.apiurl":        {"LASERBEAK_PLAYOPTIONS_APIURL", "PLAYOPTIONS_APIURL"},
+		"playoptions.cachettl":      {"LASERBEAK_PLAYOPTIONS_CACHETTL", "PLAYOPTIONS_CACHETTL"},
+		"playoptions.localmatching": {"LASERBEAK_PLAYOPTIONS_LOCALMATCHING", "PLAYOPTIONS_LOCALMATCHING"},
+		"playoptions.phonetic":      {"LASERBEAK_PLAYOPTIONS_PHONETIC", "PLAYOPTIONS_PHONETIC"},
 	}
 	for key, envVars := range envBindings {
 		viper.BindEnv(key, envVars[0], envVars[1])
@@ -98,9 +159,12 @@ func Load() (*Config, error) {
 	viper.SetDefault("llm.model", "gpt-4")
 	viper.SetDefault("stt.baseurl", "https://api.openai.com/v1")
 	viper.SetDefault("stt.model", "whisper-1")
+	viper.SetDefault("stt.fallback.baseurl", "https://api.openai.com/v1")
+	viper.SetDefault("stt.fallback.model", "whisper-1")
 	viper.SetDefault("bot.systemprompt", "You are Laserbeak, a helpful Discord assistant. Respond concisely and helpfully.")
 	viper.SetDefault("bot.maxhistory", 50)
 	viper.SetDefault("bot.wakephrase", "laser")
+	viper.SetDefault("bot.turnoffcommand", "stop")
 	viper.SetDefault("playoptions.cachettl", "5m")
 
 	// Read config file (optional)
@@ -119,29 +183,65 @@ func Load() (*Config, error) {
 			TextChannelID:  viper.GetString("discord.textchannelid"),
 		},
 		LLM: LLMConfig{
-			APIKey:  viper.GetString("llm.apikey"),
-			BaseURL: viper.GetString("llm.baseurl"),
-			Model:   viper.GetString("llm.model"),
+			APIKey:          viper.GetString("llm.apikey"),
+			BaseURL:         viper.GetString("llm.baseurl"),
+			Model:           viper.GetString("llm.model"),
+			MaxConcurrent:   viper.GetInt("llm.maxconcurrent"),
+			BusyPassthrough: viper.GetBool("llm.busypassthrough"),
 		},
 		STT: STTConfig{
-			APIKey:  viper.GetString("stt.apikey"),
-			BaseURL: viper.GetString("stt.baseurl"),
-			Model:   viper.GetString("stt.model"),
+			APIKey:        viper.GetString("stt.apikey"),
+			BaseURL:       viper.GetString("stt.baseurl"),
+			Model:         viper.GetString("stt.model"),
+			SpeakerLabels: viper.GetStringSlice("stt.speakerlabels"),
+			MaxChunkBytes: viper.GetInt("stt.maxchunkbytes"),
+			Fallback: STTProviderConfig{
+				APIKey:  viper.GetString("stt.fallback.apikey"),
+				BaseURL: viper.GetString("stt.fallback.baseurl"),
+				Model:   viper.GetString("stt.fallback.model"),
+			},
 		},
 		Bot: BotConfig{
-			SystemPrompt: viper.GetString("bot.systemprompt"),
-			MaxHistory:   viper.GetInt("bot.maxhistory"),
-			WakePhrase:   viper.GetString("bot.wakephrase"),
+			SystemPrompt:     viper.GetString("bot.systemprompt"),
+			MaxHistory:       viper.GetInt("bot.maxhistory"),
+			WakePhrase:       viper.GetString("bot.wakephrase"),
+			FuzzyKeywords:    viper.GetBool("bot.fuzzykeywords"),
+			WakeSensitivity:  viper.GetInt("bot.wakesensitivity"),
+			TrailingWake:     viper.GetBool("bot.trailingwake"),
+			FirstWordWake:    viper.GetInt("bot.firstwordwake"),
+			NoiseWords:       viper.GetStringSlice("bot.noisewords"),
+			Placeholders:     viper.GetStringSlice("bot.placeholdertokens"),
+			MinCommandWords:  viper.GetInt("bot.mincommandwords"),
+			MaxIntervening:   viper.GetInt("bot.maxinterveningwords"),
+			Aliases:          viper.GetStringMapString("bot.aliases"),
+			Homophones:       viper.GetStringMapString("bot.homophones"),
+			CommandPrefixes:  viper.GetStringMapString("bot.commandprefixes"),
+			DeniedUsers:      viper.GetStringSlice("bot.deniedusers"),
+			AllowedUsers:     viper.GetStringSlice("bot.allowedusers"),
+			AdminUsers:       viper.GetStringSlice("bot.adminusers"),
+			DisabledCommands: viper.GetStringSlice("bot.disabledcommands"),
+			HistoryFile:      viper.GetString("bot.historyfile"),
+			TurnOffCommand:   viper.GetString("bot.turnoffcommand"),
+			BareWakeCommand:  viper.GetString("bot.barewakecommand"),
+			RandomSynonyms:   viper.GetStringSlice("bot.randomsynonyms"),
+			RandomOutput:     viper.GetString("bot.randomoutput"),
 		},
 	}
 
+	// An unset or invalid TTL leaves the transcription cache disabled.
+	if sttCacheTTL, err := time.ParseDuration(viper.GetString("stt.cachettl")); err == nil {
+		cfg.STT.CacheTTL = sttCacheTTL
+	}
+
 	cacheTTL, err := time.ParseDuration(viper.GetString("playoptions.cachettl"))
 	if err != nil {
 		cacheTTL = 5 * time.Minute
 	}
 	cfg.PlayOptions = PlayOptionsConfig{
-		APIURL:   viper.GetString("playoptions.apiurl"),
-		CacheTTL: cacheTTL,
+		APIURL:        viper.GetString("playoptions.apiurl"),
+		CacheTTL:      cacheTTL,
+		LocalMatching: viper.GetBool("playoptions.localmatching"),
+		Phonetic:      viper.GetBool("playoptions.phonetic"),
 	}
 
 	if cfg.Discord.Token == "" {
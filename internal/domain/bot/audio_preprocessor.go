@@ -0,0 +1,10 @@
+package bot
+
+import "context"
+
+// AudioPreprocessor defines the port for transforming audio before transcription,
+// e.g. decoding or resampling into the format the STT service expects.
+type AudioPreprocessor interface {
+	// Process returns the audio to transcribe in place of the input.
+	Process(ctx context.Context, audio []byte) ([]byte, error)
+}
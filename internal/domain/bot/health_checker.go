@@ -0,0 +1,10 @@
+package bot
+
+import "context"
+
+// HealthChecker is implemented by services that can check they are reachable,
+// e.g. an STT or LLM client that can authenticate without doing real work.
+type HealthChecker interface {
+	// Ping returns an error if the service can't currently be used.
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,28 @@
+package bot
+
+import (
+	"context"
+	"time"
+)
+
+// CommandRecord is a voice command that was sent to chat.
+type CommandRecord struct {
+	// Time is when the command was handled.
+	Time time.Time
+	// UserID is the user who spoke the command.
+	UserID string
+	// Name identifies the command (e.g. "stop", "play").
+	Name string
+	// Text is the command text that was sent.
+	Text string
+}
+
+// HistoryStore defines the port for persisting recent voice command history,
+// so it survives restarts.
+type HistoryStore interface {
+	// Load returns the saved commands by channel ID, oldest first.
+	Load(ctx context.Context) (map[string][]CommandRecord, error)
+	// Save replaces the saved commands for a channel, oldest first. An empty
+	// list removes the channel.
+	Save(ctx context.Context, channelID string, commands []CommandRecord) error
+}
@@ -0,0 +1,9 @@
+package bot
+
+// PlaybackState defines the port for querying what the music bot is doing.
+type PlaybackState interface {
+	// IsPlaying reports whether a track is currently playing.
+	IsPlaying() bool
+	// HasQueue reports whether any tracks are queued.
+	HasQueue() bool
+}
@@ -7,3 +7,21 @@ type STTService interface {
 	// Transcribe converts raw audio (Opus/PCM) into text.
 	Transcribe(ctx context.Context, audioData []byte) (string, error)
 }
+
+// AudioFormat describes how audio passed to an STT service is encoded.
+type AudioFormat struct {
+	// SampleRate is the number of samples per second, e.g. 48000.
+	SampleRate int
+	// Channels is 1 for mono or 2 for stereo.
+	Channels int
+	// Encoding names the container or codec, e.g. "wav" or "opus".
+	Encoding string
+}
+
+// FormatSTTService is an STTService that can use the audio format, for
+// providers that need the sample rate or encoding to decode raw audio.
+type FormatSTTService interface {
+	STTService
+	// TranscribeFormat is like Transcribe but is told how the audio is encoded.
+	TranscribeFormat(ctx context.Context, audioData []byte, format AudioFormat) (string, error)
+}
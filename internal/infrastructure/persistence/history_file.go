@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+// FileHistoryStore implements bot.HistoryStore with a JSON file holding every
+// channel's recent commands.
+type FileHistoryStore struct {
+	mu    sync.Mutex
+	path  string
+	saved map[string][]bot.CommandRecord // contents of the file, loaded lazily
+}
+
+// NewFileHistoryStore creates a FileHistoryStore that reads and writes path.
+func NewFileHistoryStore(path string) *FileHistoryStore {
+	return &FileHistoryStore{path: path}
+}
+
+// Load reads the saved history. A missing file means no history.
+func (f *FileHistoryStore) Load(ctx context.Context) (map[string][]bot.CommandRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.read(); err != nil {
+		return nil, err
+	}
+	out := make(map[string][]bot.CommandRecord, len(f.saved))
+	for channelID, commands := range f.saved {
+		out[channelID] = append([]bot.CommandRecord(nil), commands...)
+	}
+	return out, nil
+}
+
+// Save replaces a channel's history and rewrites the file. The file is written
+// to a temporary name first so a crash can't leave it half written.
+func (f *FileHistoryStore) Save(ctx context.Context, channelID string, commands []bot.CommandRecord) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.read(); err != nil {
+		return err
+	}
+	if len(commands) == 0 {
+		delete(f.saved, channelID)
+	} else {
+		f.saved[channelID] = append([]bot.CommandRecord(nil), commands...)
+	}
+
+	data, err := json.Marshal(f.saved)
+	if err != nil {
+		return fmt.Errorf("encode history: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write history: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("write history: %w", err)
+	}
+	return nil
+}
+
+// read loads the file into f.saved the first time it's needed. On error
+// f.saved stays nil, so the next call tries again rather than a Save
+// overwriting the file with an empty history.
+func (f *FileHistoryStore) read() error {
+	if f.saved != nil {
+		return nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			f.saved = make(map[string][]bot.CommandRecord)
+			return nil
+		}
+		return fmt.Errorf("read history: %w", err)
+	}
+	var saved map[string][]bot.CommandRecord
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("decode history: %w", err)
+	}
+	if saved == nil {
+		saved = make(map[string][]bot.CommandRecord)
+	}
+	f.saved = saved
+	return nil
+}
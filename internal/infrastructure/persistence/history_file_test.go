@@ -0,0 +1,88 @@
+package persistence
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adrock-miles/go-laserbeak/internal/domain/bot"
+)
+
+func TestFileHistoryStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "history.json")
+	commands := []bot.CommandRecord{
+		{Time: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), UserID: "u1", Name: "play", Text: "!play Justice"},
+		{Time: time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC), UserID: "u2", Name: "stop", Text: "!stop"},
+	}
+
+	store := NewFileHistoryStore(path)
+	if got, err := store.Load(ctx); err != nil || len(got) != 0 {
+		t.Fatalf("Load without a file = %v, %v; want no history", got, err)
+	}
+	if err := store.Save(ctx, "ch1", commands); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Save(ctx, "ch2", commands[:1]); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Save(ctx, "ch2", nil); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := NewFileHistoryStore(path).Load(ctx)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if want := map[string][]bot.CommandRecord{"ch1": commands}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Load = %v, want %v", got, want)
+	}
+}
+
+func TestFileHistoryStore_CorruptFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "history.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileHistoryStore(path)
+	if _, err := store.Load(ctx); err == nil {
+		t.Fatal("Load of a corrupt file succeeded, want an error")
+	}
+	// A failed read isn't mistaken for an empty history.
+	if err := store.Save(ctx, "ch1", []bot.CommandRecord{{Name: "stop", Text: "!stop"}}); err == nil {
+		t.Fatal("Save after a failed read succeeded, want an error")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{not json" {
+		t.Errorf("file = %q, want it left as it was", data)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"ch1":[{"Name":"stop","Text":"!stop"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Load(ctx)
+	if err != nil || len(got["ch1"]) != 1 {
+		t.Errorf("Load after repair = %v, %v; want the repaired history", got, err)
+	}
+}
+
+func TestFileHistoryStore_ReadError(t *testing.T) {
+	ctx := context.Background()
+	// A directory can't be read as a file, but isn't missing either.
+	path := t.TempDir()
+
+	store := NewFileHistoryStore(path)
+	if _, err := store.Load(ctx); err == nil {
+		t.Fatal("Load of an unreadable path succeeded, want an error")
+	}
+	if err := store.Save(ctx, "ch1", []bot.CommandRecord{{Name: "stop", Text: "!stop"}}); err == nil {
+		t.Error("Save after a read error succeeded, want an error")
+	}
+	if store.saved != nil {
+		t.Errorf("saved = %v after read errors, want nil", store.saved)
+	}
+}